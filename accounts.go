@@ -41,5 +41,5 @@ func (s *AccountService) Accounts(ctx context.Context) ([]*Account, *http.Respon
 		return nil, resp, err
 	}
 
-	return accounts, nil, nil
+	return accounts, resp, nil
 }
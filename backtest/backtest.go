@@ -0,0 +1,101 @@
+// Package backtest evaluates trading strategies offline on recorded Upbit
+// market data.
+//
+// A Source replays candles, tickers, orderbooks and trades chronologically,
+// from JSON-lines files read with Open, from candles fetched with
+// CandleService.Range, or from several of them interleaved with Merge. Run
+// feeds every event to a simulated Exchange and then to the Strategy, which
+// trades through the same upbit.Trader interface as live code:
+//
+//	src, err := backtest.Open("KRW-BTC.jsonl.gz")
+//	...
+//	report, err := backtest.Run(ctx, src, backtest.StrategyFunc(
+//		func(ctx context.Context, trader upbit.Trader, e *backtest.Event) error {
+//			_, _, err := trader.Order(ctx, &upbit.OrderRequest{...})
+//			return err
+//		}), &backtest.Options{Balances: map[string]upbit.Decimal{"KRW": "1000000"}})
+//
+// The Report holds the equity curve, the fills and summary statistics.
+package backtest
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+// Strategy is called with every event after the Exchange has matched the
+// waiting orders against it. Orders placed fill against the market as of the
+// event, so a strategy acting on a candle trades at its close. Returning an
+// error stops Run.
+type Strategy interface {
+	OnEvent(ctx context.Context, trader upbit.Trader, e *Event) error
+}
+
+// StrategyFunc adapts a function to a Strategy.
+type StrategyFunc func(ctx context.Context, trader upbit.Trader, e *Event) error
+
+func (f StrategyFunc) OnEvent(ctx context.Context, trader upbit.Trader, e *Event) error {
+	return f(ctx, trader, e)
+}
+
+// Run replays src through strategy on a new Exchange with opts and reports
+// the result. An event earlier than the one before it, such as a ticker a
+// few milliseconds behind a trade on a recorded stream, counts as happening
+// at the same time.
+func Run(ctx context.Context, src Source, strategy Strategy, opts *Options) (*Report, error) {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	ex := NewExchange(opts)
+
+	var (
+		curve   []EquityPoint
+		initial upbit.Decimal
+		start   time.Time
+		last    time.Time
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		e, err := src.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		t := e.Time()
+		if t.Before(last) {
+			t = last
+		}
+		last = t
+
+		if err := ex.Update(e); err != nil {
+			return nil, err
+		}
+		if initial == "" {
+			initial, start = ex.Equity(), t
+		}
+		if err := strategy.OnEvent(ctx, ex, e); err != nil {
+			return nil, err
+		}
+
+		point := EquityPoint{
+			Time:   t.Truncate(ex.opts.EquityInterval),
+			Equity: ex.Equity().Float64(),
+		}
+		if n := len(curve); n > 0 && curve[n-1].Time.Equal(point.Time) {
+			curve[n-1] = point
+		} else {
+			curve = append(curve, point)
+		}
+	}
+
+	return newReport(ex, initial.Float64(), start, curve), nil
+}
@@ -0,0 +1,223 @@
+package backtest_test
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+	"github.com/investing-kr/go-upbit/backtest"
+)
+
+func hourCandle(hour int, open, high, low, close upbit.Decimal) *upbit.Candle {
+	start := time.Date(2021, 1, 1, hour, 0, 0, 0, time.UTC)
+	return &upbit.Candle{
+		Market:               upbit.KRW_BTC,
+		CandleDateTimeUtc:    start.Format("2006-01-02T15:04:05"),
+		OpeningPrice:         open,
+		HighPrice:            high,
+		LowPrice:             low,
+		TradePrice:           close,
+		Timestamp:            start.Add(59*time.Minute).UnixNano() / int64(time.Millisecond),
+		CandleAccTradeVolume: "1",
+	}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestRun(t *testing.T) {
+	candles := []*upbit.Candle{
+		hourCandle(3, "11000", "12500", "11000", "12000"),
+		hourCandle(2, "11000", "11000", "8500", "9000"),
+		hourCandle(1, "10000", "11000", "10000", "11000"),
+		hourCandle(0, "10000", "10000", "10000", "10000"),
+	}
+
+	var events int
+	strategy := backtest.StrategyFunc(func(ctx context.Context, trader upbit.Trader, e *backtest.Event) error {
+		events++
+		switch events {
+		case 1:
+			if _, _, err := trader.Order(ctx, &upbit.OrderRequest{
+				Market: upbit.KRW_BTC, Side: upbit.SideBid, OrdType: upbit.OrdTypePrice, Price: "1000",
+			}); !errors.Is(err, upbit.ErrUnderMinTotalBid) {
+				t.Errorf("bid below the minimum total: %v", err)
+			}
+			order, _, err := trader.Order(ctx, &upbit.OrderRequest{
+				Market: upbit.KRW_BTC, Side: upbit.SideBid, OrdType: upbit.OrdTypePrice, Price: "100000",
+			})
+			if err != nil {
+				return err
+			}
+			if order.State != upbit.OrderStateDone || !order.ExecutedVolume.Equal("10") || !order.PaidFee.Equal("50") {
+				t.Errorf("bid = %+v", order)
+			}
+		case 3:
+			if _, _, err := trader.Order(ctx, &upbit.OrderRequest{
+				Market: upbit.KRW_BTC, Side: upbit.SideAsk, OrdType: upbit.OrdTypeLimit, Price: "12001", Volume: "10",
+			}); !errors.Is(err, upbit.ErrInvalidPriceUnit) {
+				t.Errorf("ask off the price unit: %v", err)
+			}
+			order, _, err := trader.Order(ctx, &upbit.OrderRequest{
+				Market: upbit.KRW_BTC, Side: upbit.SideAsk, OrdType: upbit.OrdTypeLimit, Price: "12000", Volume: "10",
+			})
+			if err != nil {
+				return err
+			}
+			if order.State != upbit.OrderStateWait {
+				t.Errorf("ask above the close = %+v", order)
+			}
+		}
+		return nil
+	})
+
+	report, err := backtest.Run(context.Background(), backtest.Candles(candles), strategy, &backtest.Options{
+		Balances: map[string]upbit.Decimal{"KRW": "1000000"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Fills) != 2 || !report.Fills[1].Price.Equal("12000") || !report.Fills[1].Fee.Equal("60") {
+		t.Errorf("fills = %+v", report.Fills)
+	}
+	want := []float64{999950, 1009950, 989950, 1019890}
+	if len(report.Equity) != len(want) {
+		t.Fatalf("equity = %+v", report.Equity)
+	}
+	for i, p := range report.Equity {
+		if !near(p.Equity, want[i]) || p.Time.Hour() != i {
+			t.Errorf("equity[%d] = %+v, want %v", i, p, want[i])
+		}
+	}
+	if !near(report.InitialEquity, 1000000) || !near(report.FinalEquity, 1019890) || !near(report.Return, 0.01989) {
+		t.Errorf("equity %v to %v, return %v", report.InitialEquity, report.FinalEquity, report.Return)
+	}
+	if !near(report.MaxDrawdown, 20000.0/1009950) {
+		t.Errorf("max drawdown = %v", report.MaxDrawdown)
+	}
+	if !near(report.Volume, 220000) || !near(report.Fees, 110) || !near(report.Turnover, 220000/((999950+1009950+989950+1019890)/4.0)) {
+		t.Errorf("volume %v, fees %v, turnover %v", report.Volume, report.Fees, report.Turnover)
+	}
+	if report.Sharpe <= 0 {
+		t.Errorf("sharpe = %v", report.Sharpe)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	ctx := context.Background()
+	ex := backtest.NewExchange(&backtest.Options{
+		Balances: map[string]upbit.Decimal{"KRW": "1000000", "BTC": "1"},
+		Fee:      "0.001",
+	})
+
+	if _, _, err := ex.Order(ctx, &upbit.OrderRequest{
+		Market: upbit.KRW_BTC, Side: upbit.SideAsk, OrdType: upbit.OrdTypeMarket, Volume: "1",
+	}); !errors.Is(err, backtest.ErrNoQuote) {
+		t.Errorf("market ask without a quote: %v", err)
+	}
+
+	bid, _, err := ex.Order(ctx, &upbit.OrderRequest{
+		Market: upbit.KRW_BTC, Side: upbit.SideBid, OrdType: upbit.OrdTypeLimit, Price: "10000", Volume: "10",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ms := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano() / int64(time.Millisecond)
+	ex.Update(&backtest.Event{Trade: &upbit.TradeTick{Market: upbit.KRW_BTC, Timestamp: ms, TradePrice: "9990", TradeVolume: "4"}})
+	bid, _, _ = ex.GetOrderByUUID(ctx, bid.UUID)
+	if bid.State != upbit.OrderStateWait || !bid.ExecutedVolume.Equal("4") {
+		t.Errorf("bid after a trade of 4 = %+v", bid)
+	}
+
+	ex.Update(&backtest.Event{Orderbook: &upbit.Orderbook{
+		Market:    upbit.KRW_BTC,
+		Timestamp: ms + 1000,
+		OrderbookUnits: []upbit.OrderbookUnit{
+			{AskPrice: "9980", AskSize: "2", BidPrice: "9970", BidSize: "0.5"},
+			{AskPrice: "9990", AskSize: "10", BidPrice: "9960", BidSize: "0.3"},
+		},
+	}})
+	bid, _, _ = ex.GetOrderByUUID(ctx, bid.UUID)
+	if bid.State != upbit.OrderStateDone || !bid.PaidFee.Equal("100") {
+		t.Errorf("bid after the book crossed = %+v", bid)
+	}
+
+	ask, _, err := ex.Order(ctx, &upbit.OrderRequest{
+		Market: upbit.KRW_BTC, Side: upbit.SideAsk, OrdType: upbit.OrdTypeMarket, Volume: "1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 0.5 at 9970 and 0.3 at 9960 are all the book has.
+	if ask.State != upbit.OrderStateCancel || !ask.ExecutedVolume.Equal("0.8") {
+		t.Errorf("market ask = %+v", ask)
+	}
+
+	accounts, _, _ := ex.Accounts(ctx)
+	// 1,000,000 - 100,000 - 100 + 7,973 - 7.973
+	if len(accounts) != 2 || !accounts[0].Balance.Equal("10.2") || !accounts[1].Balance.Equal("907865.027") {
+		t.Errorf("accounts = %+v, %+v", accounts[0], accounts[1])
+	}
+	if equity := ex.Equity(); !equity.Equal("1009763.027") {
+		t.Errorf("equity = %s", equity)
+	}
+}
+
+func TestReader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "KRW-BTC.jsonl.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	w := backtest.NewWriter(gz)
+	for _, e := range []*backtest.Event{
+		{Candle: hourCandle(0, "10000", "10000", "10000", "10000")},
+		{Ticker: &upbit.Ticker{Code: upbit.KRW_BTC, TradePrice: "10010", TradeTimestamp: 1609462800000}},
+	} {
+		if err := w.Write(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gz.Close()
+	f.Close()
+
+	r, err := backtest.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	trades := backtest.Events([]*backtest.Event{
+		{Trade: &upbit.TradeTick{Market: upbit.KRW_ETH, TradePrice: "700000", Timestamp: 1609462000000}},
+	})
+	src := backtest.Merge(r, trades)
+
+	var types []backtest.EventType
+	for {
+		e, err := src.Next()
+		if err != nil {
+			break
+		}
+		types = append(types, e.Type())
+	}
+	if len(types) != 3 || types[0] != backtest.EventTrade || types[1] != backtest.EventCandle || types[2] != backtest.EventTicker {
+		t.Errorf("types = %v", types)
+	}
+}
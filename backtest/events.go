@@ -0,0 +1,327 @@
+package backtest
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+// EventType is the kind of market data an Event carries.
+type EventType string
+
+const (
+	EventCandle    EventType = "candle"
+	EventTicker    EventType = "ticker"
+	EventOrderbook EventType = "orderbook"
+	EventTrade     EventType = "trade"
+)
+
+// Event is one piece of market data. Exactly one of its fields is set.
+//
+// In files an event is a JSON line holding its type and the Upbit JSON of
+// the data:
+//
+//	{"type":"candle","data":{"market":"KRW-BTC","candle_date_time_utc":...}}
+type Event struct {
+	Candle    *upbit.Candle
+	Ticker    *upbit.Ticker
+	Orderbook *upbit.Orderbook
+	Trade     *upbit.TradeTick
+}
+
+func (e *Event) Type() EventType {
+	switch {
+	case e.Candle != nil:
+		return EventCandle
+	case e.Ticker != nil:
+		return EventTicker
+	case e.Orderbook != nil:
+		return EventOrderbook
+	case e.Trade != nil:
+		return EventTrade
+	}
+	return ""
+}
+
+func (e *Event) Market() string {
+	switch {
+	case e.Candle != nil:
+		return e.Candle.Market
+	case e.Ticker != nil:
+		if e.Ticker.Market == "" {
+			return e.Ticker.Code
+		}
+		return e.Ticker.Market
+	case e.Orderbook != nil:
+		if e.Orderbook.Market == "" {
+			return e.Orderbook.Code
+		}
+		return e.Orderbook.Market
+	case e.Trade != nil:
+		return e.Trade.Market
+	}
+	return ""
+}
+
+// Time returns when the event happened. A candle happens at its last trade,
+// so a strategy sees it complete; candles without a timestamp happen at
+// their start.
+func (e *Event) Time() time.Time {
+	var ms int64
+	switch {
+	case e.Candle != nil:
+		if e.Candle.Timestamp == 0 {
+			t, _ := time.Parse("2006-01-02T15:04:05", e.Candle.CandleDateTimeUtc)
+			return t
+		}
+		ms = e.Candle.Timestamp
+	case e.Ticker != nil:
+		ms = e.Ticker.TradeTimestamp
+		if ms == 0 {
+			ms = e.Ticker.Timestamp
+		}
+	case e.Orderbook != nil:
+		ms = e.Orderbook.Timestamp
+	case e.Trade != nil:
+		ms = e.Trade.Timestamp
+	}
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
+
+type eventJSON struct {
+	Type EventType       `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+func (e *Event) MarshalJSON() ([]byte, error) {
+	var data interface{}
+	switch e.Type() {
+	case EventCandle:
+		data = e.Candle
+	case EventTicker:
+		data = e.Ticker
+	case EventOrderbook:
+		data = e.Orderbook
+	case EventTrade:
+		data = e.Trade
+	default:
+		return nil, fmt.Errorf("backtest: empty event")
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&eventJSON{Type: e.Type(), Data: b})
+}
+
+func (e *Event) UnmarshalJSON(b []byte) error {
+	var v eventJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*e = Event{}
+	var data interface{}
+	switch v.Type {
+	case EventCandle:
+		e.Candle = &upbit.Candle{}
+		data = e.Candle
+	case EventTicker:
+		e.Ticker = &upbit.Ticker{}
+		data = e.Ticker
+	case EventOrderbook:
+		e.Orderbook = &upbit.Orderbook{}
+		data = e.Orderbook
+	case EventTrade:
+		e.Trade = &upbit.TradeTick{}
+		data = e.Trade
+	default:
+		return fmt.Errorf("backtest: unknown event type %q", v.Type)
+	}
+	return json.Unmarshal(v.Data, data)
+}
+
+// Source yields events in chronological order. Next returns io.EOF after the
+// last event.
+type Source interface {
+	Next() (*Event, error)
+}
+
+// maxLineSize bounds a JSON line, large enough for a 30 level orderbook.
+const maxLineSize = 1 << 20
+
+// Reader reads events from JSON lines.
+type Reader struct {
+	scanner *bufio.Scanner
+	closers []io.Closer
+	line    int
+}
+
+func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	return &Reader{scanner: scanner}
+}
+
+// Open returns a Reader of the file at path, decompressing it if its name
+// ends with .gz.
+func Open(path string) (*Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		r := NewReader(f)
+		r.closers = []io.Closer{f}
+		return r, nil
+	}
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	r := NewReader(gz)
+	r.closers = []io.Closer{gz, f}
+	return r, nil
+}
+
+// Next returns the next event, skipping blank lines.
+func (r *Reader) Next() (*Event, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := r.scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		e := &Event{}
+		if err := json.Unmarshal(line, e); err != nil {
+			return nil, fmt.Errorf("backtest: line %d: %w", r.line, err)
+		}
+		return e, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// Close closes the file opened by Open.
+func (r *Reader) Close() error {
+	var err error
+	for _, c := range r.closers {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Writer writes events as JSON lines, to be read back by Reader.
+type Writer struct {
+	enc *json.Encoder
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{enc: json.NewEncoder(w)}
+}
+
+func (w *Writer) Write(e *Event) error {
+	return w.enc.Encode(e)
+}
+
+type sliceSource struct {
+	events []*Event
+}
+
+func (s *sliceSource) Next() (*Event, error) {
+	if len(s.events) == 0 {
+		return nil, io.EOF
+	}
+	e := s.events[0]
+	s.events = s.events[1:]
+	return e, nil
+}
+
+// Events returns a Source of events, sorted chronologically.
+func Events(events []*Event) Source {
+	sorted := append([]*Event(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time().Before(sorted[j].Time())
+	})
+	return &sliceSource{events: sorted}
+}
+
+// Candles returns a Source of candles, such as the result of
+// CandleService.Range. They may be newest or oldest first.
+func Candles(candles []*upbit.Candle) Source {
+	events := make([]*Event, len(candles))
+	for i, c := range candles {
+		events[i] = &Event{Candle: c}
+	}
+	return Events(events)
+}
+
+type mergeSource struct {
+	sources []Source
+	heads   []*Event
+	started bool
+}
+
+// Merge interleaves the events of sources chronologically, such as the
+// files of several markets. Events at the same time come in the order of
+// sources.
+func Merge(sources ...Source) Source {
+	return &mergeSource{sources: sources, heads: make([]*Event, len(sources))}
+}
+
+func (m *mergeSource) Next() (*Event, error) {
+	if !m.started {
+		for i := range m.sources {
+			if err := m.advance(i); err != nil {
+				return nil, err
+			}
+		}
+		m.started = true
+	}
+
+	next := -1
+	for i, e := range m.heads {
+		if e != nil && (next < 0 || e.Time().Before(m.heads[next].Time())) {
+			next = i
+		}
+	}
+	if next < 0 {
+		return nil, io.EOF
+	}
+
+	e := m.heads[next]
+	if err := m.advance(next); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (m *mergeSource) advance(i int) error {
+	e, err := m.sources[i].Next()
+	if err == io.EOF {
+		m.heads[i] = nil
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	m.heads[i] = e
+	return nil
+}
@@ -0,0 +1,412 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+	"github.com/investing-kr/go-upbit/internal/ledger"
+	"github.com/investing-kr/go-upbit/internal/ledger/ledgerconv"
+)
+
+// ErrNoQuote is returned for a market or price order in a market no event
+// has priced yet.
+var ErrNoQuote = fmt.Errorf("backtest: no quote for the market yet")
+
+// Fees are Upbit's fee rates by quote currency. Markets of other quote
+// currencies pay DefaultFee.
+var Fees = map[string]upbit.Decimal{
+	"KRW":  "0.0005",
+	"BTC":  "0.0025",
+	"USDT": "0.0025",
+}
+
+const DefaultFee upbit.Decimal = "0.0025"
+
+type Options struct {
+	// Balances are the initial available balances by currency.
+	Balances map[string]upbit.Decimal
+
+	// Fee is the fee rate of both sides of every market. Empty takes the
+	// rate of the market's quote currency from Fees.
+	Fee upbit.Decimal
+
+	// Region decides the price units of limit orders and the currency
+	// equity is valued in. Empty is upbit.RegionKR.
+	Region upbit.Region
+
+	// RoundPrices rounds limit prices off the price unit in the direction
+	// favorable to the order, like upbit.PriceUnitRound. Otherwise they are
+	// rejected with upbit.ErrInvalidPriceUnit, as Upbit does.
+	RoundPrices bool
+
+	// EquityInterval is the period of the equity curve of the Report.
+	// Defaults to an hour.
+	EquityInterval time.Duration
+}
+
+// Exchange is a simulated Upbit exchange driven by events. It implements
+// upbit.Trader, so a strategy written against it runs unchanged on a
+// Client.Trader or a PaperTrader.
+//
+// Orders lock funds like Upbit does and fill on placement against the last
+// orderbook of the market, level by level, or at the last trade price if no
+// orderbook was seen. The rest of a limit order waits and fills at its price
+// when later events cross it: a candle whose range reaches it, a ticker or
+// orderbook through it, or trades at or through it, up to their volume. The
+// rest of market and price orders is cancelled.
+//
+// Responses are always nil. Exchange is safe for concurrent use.
+type Exchange struct {
+	opts  Options
+	quote string
+
+	mu      sync.Mutex
+	now     time.Time
+	ledger  *ledger.Ledger
+	markets map[string]*market
+	fills   []Fill
+}
+
+type market struct {
+	last upbit.Decimal
+	mid  upbit.Decimal
+	// asks and bids are the liquidity left of the last orderbook, best
+	// first.
+	asks, bids []ledger.Level
+	hasBook    bool
+}
+
+// Fill is an execution of an order.
+type Fill struct {
+	Time   time.Time
+	Market string
+	UUID   string
+	Side   string
+	Price  upbit.Decimal
+	Volume upbit.Decimal
+	// Fee is paid in the quote currency of the market.
+	Fee upbit.Decimal
+}
+
+func NewExchange(opts *Options) *Exchange {
+	e := &Exchange{
+		markets: map[string]*market{},
+		quote:   "KRW",
+	}
+	if opts != nil {
+		e.opts = *opts
+	}
+	if e.opts.EquityInterval <= 0 {
+		e.opts.EquityInterval = time.Hour
+	}
+	if quotes := e.opts.Region.QuoteCurrencies(); len(quotes) > 0 {
+		e.quote = quotes[0]
+	}
+	e.ledger = ledger.New(e.quote)
+	e.ledger.OnFill = e.record
+	for currency, balance := range e.opts.Balances {
+		e.ledger.Account(currency).Balance = string(balance)
+	}
+	return e
+}
+
+// Now returns the time of the last event.
+func (e *Exchange) Now() time.Time {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.now
+}
+
+// Update advances the exchange to ev and matches the waiting orders of its
+// market against it.
+func (e *Exchange) Update(ev *Event) error {
+	code := ev.Market()
+	if code == "" {
+		return fmt.Errorf("backtest: %s event without a market", ev.Type())
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if t := ev.Time(); t.After(e.now) {
+		e.now = t
+	}
+	m := e.market(code)
+
+	// levels is what crosses the waiting orders of each side, filled at
+	// their prices. An empty Size is unlimited.
+	var asks, bids []ledger.Level
+	switch ev.Type() {
+	case EventCandle:
+		m.last = ev.Candle.TradePrice
+		asks = []ledger.Level{{Price: string(ev.Candle.LowPrice)}}
+		bids = []ledger.Level{{Price: string(ev.Candle.HighPrice)}}
+	case EventTicker:
+		m.last = ev.Ticker.TradePrice
+		asks = []ledger.Level{{Price: string(m.last)}}
+		bids = asks
+	case EventTrade:
+		m.last = ev.Trade.TradePrice
+		// Both sides share the volume of the trade.
+		asks = []ledger.Level{{Price: string(m.last), Size: string(ev.Trade.TradeVolume)}}
+		bids = asks
+	case EventOrderbook:
+		book, err := upbit.NewBook(ev.Orderbook)
+		if err != nil {
+			return err
+		}
+		m.asks, m.bids, m.hasBook = ledgerconv.Levels(book.Asks()), ledgerconv.Levels(book.Bids()), true
+		m.mid, _ = book.MidPrice()
+		asks, bids = m.asks, m.bids
+	}
+
+	for _, o := range e.ledger.Orders {
+		if o.Market != code || o.State != upbit.OrderStateWait {
+			continue
+		}
+		if o.Side == upbit.SideBid {
+			asks = e.ledger.Match(o, asks, true)
+			if ev.Type() == EventTrade {
+				bids = asks
+			}
+		} else {
+			bids = e.ledger.Match(o, bids, true)
+			if ev.Type() == EventTrade {
+				asks = bids
+			}
+		}
+	}
+	if ev.Type() == EventOrderbook {
+		m.asks, m.bids = asks, bids
+	}
+	return nil
+}
+
+func (e *Exchange) Order(ctx context.Context, orderReq *upbit.OrderRequest) (*upbit.Order, *http.Response, error) {
+	if orderReq == nil || (orderReq.Side != upbit.SideBid && orderReq.Side != upbit.SideAsk) {
+		return nil, nil, upbit.ErrInvalidArguments
+	}
+	for _, d := range []upbit.Decimal{orderReq.Price, orderReq.Volume} {
+		if v, err := upbit.NewDecimal(string(d)); err != nil || v.Sign() < 0 {
+			return nil, nil, upbit.ErrInvalidArguments
+		}
+	}
+	switch {
+	case orderReq.OrdType == upbit.OrdTypeLimit && orderReq.Price != "" && orderReq.Volume != "":
+	case orderReq.OrdType == upbit.OrdTypePrice && orderReq.Side == upbit.SideBid && orderReq.Price != "" && orderReq.Volume == "":
+	case orderReq.OrdType == upbit.OrdTypeMarket && orderReq.Side == upbit.SideAsk && orderReq.Volume != "" && orderReq.Price == "":
+	default:
+		return nil, nil, upbit.ErrInvalidArguments
+	}
+	if _, _, err := upbit.ParseMarket(orderReq.Market); err != nil {
+		return nil, nil, upbit.ErrInvalidArguments
+	}
+
+	req := *orderReq
+	if req.OrdType == upbit.OrdTypeLimit {
+		mode := upbit.RoundDown
+		if req.Side == upbit.SideAsk {
+			mode = upbit.RoundUp
+		}
+		rounded := e.opts.Region.RoundPrice(req.Market, req.Price, mode)
+		if !rounded.Equal(req.Price) {
+			if !e.opts.RoundPrices {
+				return nil, nil, upbit.ErrInvalidPriceUnit
+			}
+			req.Price = rounded
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := upbit.ValidateOrder(e.chance(req.Market), &req); err != nil {
+		return nil, nil, err
+	}
+	if req.Identifier != "" && e.ledger.Find("", req.Identifier) != nil {
+		return nil, nil, upbit.ErrInvalidArguments
+	}
+	m := e.market(req.Market)
+	if req.OrdType != upbit.OrdTypeLimit && !m.hasBook && m.last == "" {
+		return nil, nil, ErrNoQuote
+	}
+
+	o, err := e.ledger.Place(ledgerconv.Request(&req, e.fee(req.Market)), e.now)
+	if err != nil {
+		return nil, nil, ledgerconv.Error(&req, err)
+	}
+
+	levels := []ledger.Level{{Price: string(m.last)}}
+	if m.hasBook {
+		levels = m.asks
+		if o.Side == upbit.SideAsk {
+			levels = m.bids
+		}
+	}
+	if m.hasBook || m.last != "" {
+		levels = e.ledger.Match(o, levels, false)
+		if m.hasBook && o.Side == upbit.SideBid {
+			m.asks = levels
+		} else if m.hasBook {
+			m.bids = levels
+		}
+	}
+
+	return ledgerconv.Order(o), nil, nil
+}
+
+func (e *Exchange) GetOrderByUUID(ctx context.Context, uuid string) (*upbit.Order, *http.Response, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	o := e.ledger.Find(uuid, "")
+	if o == nil {
+		return nil, nil, upbit.ErrOrderNotFound
+	}
+	return ledgerconv.Order(o), nil, nil
+}
+
+func (e *Exchange) CancelOrderByUUID(ctx context.Context, uuid string) (*upbit.Order, *http.Response, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	o := e.ledger.Find(uuid, "")
+	if o == nil {
+		return nil, nil, upbit.ErrOrderNotFound
+	}
+	if o.State != upbit.OrderStateWait {
+		return nil, nil, upbit.ErrInvalidArguments
+	}
+	e.ledger.Cancel(o)
+	return ledgerconv.Order(o), nil, nil
+}
+
+// ListOrders filters the orders like Upbit: by market, by state, waiting by
+// default, or by UUIDs or identifiers, newest first unless OrderBy is "asc".
+func (e *Exchange) ListOrders(ctx context.Context, listOpt *upbit.OrderListOptions) ([]*upbit.Order, *http.Response, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return ledgerconv.Orders(e.ledger.List(ledgerconv.Query(listOpt))), nil, nil
+}
+
+// Chances returns the fees, minimum totals and accounts of market. The
+// price unit is left to the region's tables.
+func (e *Exchange) Chances(ctx context.Context, market string) (*upbit.Chance, *http.Response, error) {
+	if _, _, err := upbit.ParseMarket(market); err != nil {
+		return nil, nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.chance(market), nil, nil
+}
+
+// Accounts returns the accounts with a balance.
+func (e *Exchange) Accounts(ctx context.Context) ([]*upbit.Account, *http.Response, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return ledgerconv.Holdings(e.ledger), nil, nil
+}
+
+// Fills returns the executions so far, oldest first.
+func (e *Exchange) Fills() []Fill {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]Fill(nil), e.fills...)
+}
+
+// Equity returns the value of all balances, locked included, in the quote
+// currency of the region (KRW by default) at the last trade prices of their
+// markets against it, or the mid prices of their orderbooks before the first
+// trade. Currencies without such a market or not yet priced count as
+// nothing.
+func (e *Exchange) Equity() upbit.Decimal {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.equity()
+}
+
+func (e *Exchange) equity() upbit.Decimal {
+	equity := upbit.Decimal("0")
+	for _, a := range ledgerconv.Holdings(e.ledger) {
+		currency := a.Currency
+		held := a.Balance.Add(a.Locked)
+		if currency == e.quote {
+			equity = equity.Add(held)
+			continue
+		}
+		m, ok := e.markets[e.quote+"-"+currency]
+		if !ok {
+			continue
+		}
+		if price := m.price(); price != "" {
+			equity = equity.Add(held.Mul(price))
+		}
+	}
+	return equity
+}
+
+func (m *market) price() upbit.Decimal {
+	if m.last != "" {
+		return m.last
+	}
+	return m.mid
+}
+
+func (e *Exchange) chance(code string) *upbit.Chance {
+	quote, base, _ := upbit.ParseMarket(code)
+	fee := e.fee(code)
+
+	chance := &upbit.Chance{BidFee: fee, AskFee: fee, MakerBidFee: fee, MakerAskFee: fee}
+	chance.Market.ID = code
+	chance.Market.OrderTypes = []string{upbit.OrdTypeLimit}
+	chance.Market.BidTypes = []string{upbit.OrdTypeLimit, upbit.OrdTypePrice}
+	chance.Market.AskTypes = []string{upbit.OrdTypeLimit, upbit.OrdTypeMarket}
+	chance.Market.OrderSides = []string{upbit.SideAsk, upbit.SideBid}
+	chance.Market.Bid.Currency = quote
+	chance.Market.Bid.MinTotal = upbit.Decimal(ledger.MinTotals[quote])
+	chance.Market.Ask.Currency = base
+	chance.Market.Ask.MinTotal = upbit.Decimal(ledger.MinTotals[quote])
+	chance.Market.State = upbit.MarketStateActive
+	chance.BidAccount = *ledgerconv.Account(e.ledger.Account(quote))
+	chance.AskAccount = *ledgerconv.Account(e.ledger.Account(base))
+	return chance
+}
+
+func (e *Exchange) fee(code string) upbit.Decimal {
+	if e.opts.Fee != "" {
+		return e.opts.Fee
+	}
+	quote, _, _ := upbit.ParseMarket(code)
+	if fee, ok := Fees[quote]; ok {
+		return fee
+	}
+	return DefaultFee
+}
+
+func (e *Exchange) market(code string) *market {
+	m, ok := e.markets[code]
+	if !ok {
+		m = &market{}
+		e.markets[code] = m
+	}
+	return m
+}
+
+// record keeps a fill of the ledger.
+func (e *Exchange) record(o *ledger.Order, price, volume, fee string) {
+	e.fills = append(e.fills, Fill{
+		Time:   e.now,
+		Market: o.Market,
+		UUID:   o.UUID,
+		Side:   o.Side,
+		Price:  upbit.Decimal(price),
+		Volume: upbit.Decimal(volume),
+		Fee:    upbit.Decimal(fee),
+	})
+}
@@ -0,0 +1,121 @@
+package backtest
+
+import (
+	"math"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+// EquityPoint is the equity at the end of an Options.EquityInterval, or at
+// the last event of the run.
+type EquityPoint struct {
+	// Time is the start of the interval.
+	Time   time.Time
+	Equity float64
+}
+
+// Report summarizes a run. Amounts are in the quote currency of the region,
+// KRW by default; fills of markets quoted in other currencies are left out
+// of Volume, Fees and Turnover.
+type Report struct {
+	Start, End    time.Time
+	InitialEquity float64
+	FinalEquity   float64
+	// Return is FinalEquity relative to InitialEquity, 0.1 for 10%.
+	Return float64
+
+	// MaxDrawdown is the largest fall of the equity curve from a previous
+	// peak, relative to the peak.
+	MaxDrawdown float64
+	// Sharpe is the annualized Sharpe ratio of the returns between the
+	// points of the equity curve, with no risk-free rate and 365 trading
+	// days a year. Intervals without events are skipped, not counted as
+	// flat.
+	Sharpe float64
+	// Turnover is Volume relative to the average equity.
+	Turnover float64
+
+	// Volume is the total price of all fills and Fees the fees paid.
+	Volume float64
+	Fees   float64
+
+	Equity []EquityPoint
+	// Fills is the trade log, oldest first.
+	Fills []Fill
+}
+
+const year = 365 * 24 * time.Hour
+
+func newReport(ex *Exchange, initial float64, start time.Time, curve []EquityPoint) *Report {
+	r := &Report{
+		InitialEquity: initial,
+		Equity:        curve,
+		Fills:         ex.Fills(),
+	}
+	if len(curve) == 0 {
+		return r
+	}
+
+	r.Start, r.End = start, ex.Now()
+	r.FinalEquity = curve[len(curve)-1].Equity
+	if initial > 0 {
+		r.Return = r.FinalEquity/initial - 1
+	}
+
+	for _, f := range r.Fills {
+		if quote, _, _ := upbit.ParseMarket(f.Market); quote != ex.quote {
+			continue
+		}
+		r.Volume += f.Price.Mul(f.Volume).Float64()
+		r.Fees += f.Fee.Float64()
+	}
+
+	var (
+		peak    = initial
+		sum     float64
+		returns []float64
+	)
+	for i, p := range curve {
+		sum += p.Equity
+		if p.Equity > peak {
+			peak = p.Equity
+		}
+		if peak > 0 {
+			r.MaxDrawdown = math.Max(r.MaxDrawdown, (peak-p.Equity)/peak)
+		}
+		if i > 0 && curve[i-1].Equity > 0 {
+			returns = append(returns, p.Equity/curve[i-1].Equity-1)
+		}
+	}
+	if mean := sum / float64(len(curve)); mean > 0 {
+		r.Turnover = r.Volume / mean
+	}
+	r.Sharpe = sharpe(returns, float64(year)/float64(ex.opts.EquityInterval))
+	return r
+}
+
+// sharpe returns the mean of returns over their sample standard deviation,
+// scaled by the square root of the number of periods a year.
+func sharpe(returns []float64, periods float64) float64 {
+	n := float64(len(returns))
+	if n < 2 {
+		return 0
+	}
+
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= n
+
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	sd := math.Sqrt(variance / (n - 1))
+	if sd == 0 {
+		return 0
+	}
+	return mean / sd * math.Sqrt(periods)
+}
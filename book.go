@@ -0,0 +1,221 @@
+package upbit
+
+import (
+	"sort"
+	"sync"
+)
+
+// BookLevel is a price level of one side of a Book.
+type BookLevel struct {
+	Price Decimal
+	Size  Decimal
+}
+
+// Book is an in-memory order book of a market. Upbit publishes the order book
+// as snapshots, both on REST and on the websocket, so every Update replaces
+// the book. Book is safe for concurrent use.
+//
+// Query methods take the side of the order that would be placed: SideBid
+// walks the asks and SideAsk walks the bids.
+type Book struct {
+	mu        sync.RWMutex
+	market    string
+	timestamp int64
+	asks      []BookLevel // ascending by price
+	bids      []BookLevel // descending by price
+}
+
+// NewBook returns a Book seeded from ob, typically the result of
+// QuotationService.Orderbook, or the error of Update.
+func NewBook(ob *Orderbook) (*Book, error) {
+	b := &Book{market: ob.Market}
+	if err := b.Update(ob); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Update replaces the book with the snapshot ob. Snapshots of another market
+// or with a price or size that is not a number are rejected, and snapshots
+// older than the current one are ignored.
+func (b *Book) Update(ob *Orderbook) error {
+	market := ob.Market
+	if market == "" {
+		market = ob.Code
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.market != "" && market != b.market {
+		return ErrInvalidArguments
+	}
+	if ob.Timestamp < b.timestamp {
+		return nil
+	}
+	for _, unit := range ob.OrderbookUnits {
+		if err := checkDecimals(unit.AskPrice, unit.BidPrice, unit.AskSize, unit.BidSize); err != nil {
+			return err
+		}
+	}
+
+	asks := make([]BookLevel, 0, len(ob.OrderbookUnits))
+	bids := make([]BookLevel, 0, len(ob.OrderbookUnits))
+	for _, unit := range ob.OrderbookUnits {
+		if unit.AskSize.Sign() > 0 {
+			asks = append(asks, BookLevel{Price: unit.AskPrice, Size: unit.AskSize})
+		}
+		if unit.BidSize.Sign() > 0 {
+			bids = append(bids, BookLevel{Price: unit.BidPrice, Size: unit.BidSize})
+		}
+	}
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price.LessThan(asks[j].Price) })
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price.GreaterThan(bids[j].Price) })
+
+	b.market = market
+	b.timestamp = ob.Timestamp
+	b.asks = asks
+	b.bids = bids
+	return nil
+}
+
+func (b *Book) Market() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.market
+}
+
+func (b *Book) Timestamp() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.timestamp
+}
+
+// Asks returns a copy of the ask levels, best first.
+func (b *Book) Asks() []BookLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]BookLevel(nil), b.asks...)
+}
+
+// Bids returns a copy of the bid levels, best first.
+func (b *Book) Bids() []BookLevel {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]BookLevel(nil), b.bids...)
+}
+
+func (b *Book) BestAsk() (BookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if len(b.asks) == 0 {
+		return BookLevel{}, false
+	}
+	return b.asks[0], true
+}
+
+func (b *Book) BestBid() (BookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if len(b.bids) == 0 {
+		return BookLevel{}, false
+	}
+	return b.bids[0], true
+}
+
+// Spread returns best ask minus best bid, or false if a side is empty.
+func (b *Book) Spread() (Decimal, bool) {
+	ask, bid, ok := b.best()
+	if !ok {
+		return "", false
+	}
+	return ask.Sub(bid), true
+}
+
+func (b *Book) MidPrice() (Decimal, bool) {
+	ask, bid, ok := b.best()
+	if !ok {
+		return "", false
+	}
+	return ask.Add(bid).Div("2"), true
+}
+
+// best returns the best ask and bid prices of the same snapshot.
+func (b *Book) best() (ask, bid Decimal, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if len(b.asks) == 0 || len(b.bids) == 0 {
+		return "", "", false
+	}
+	return b.asks[0].Price, b.bids[0].Price, true
+}
+
+func (b *Book) levels(side string) []BookLevel {
+	if side == SideBid {
+		return b.asks
+	}
+	return b.bids
+}
+
+// DepthAt returns the volume an order of side can fill at price or better.
+func (b *Book) DepthAt(side string, price Decimal) Decimal {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	depth := Decimal("0")
+	for _, level := range b.levels(side) {
+		if side == SideBid && level.Price.GreaterThan(price) || side != SideBid && level.Price.LessThan(price) {
+			break
+		}
+		depth = depth.Add(level.Size)
+	}
+	return depth
+}
+
+// VolumeForNotional returns the volume an order of side fills for notional,
+// in quote currency (e.g. KRW), and the worst price it reaches. ok is false if
+// the visible book is not deep enough, in which case the volume of the whole
+// side is returned.
+func (b *Book) VolumeForNotional(side string, notional Decimal) (volume, worstPrice Decimal, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	volume = "0"
+	remaining := notional
+	for _, level := range b.levels(side) {
+		worstPrice = level.Price
+		cost := level.Price.Mul(level.Size)
+		if !cost.LessThan(remaining) {
+			return volume.Add(remaining.Div(level.Price)), worstPrice, true
+		}
+		volume = volume.Add(level.Size)
+		remaining = remaining.Sub(cost)
+	}
+	return volume, worstPrice, false
+}
+
+// VWAP returns the volume weighted average price of an order of side filling
+// volume. ok is false if the visible book is not deep enough.
+func (b *Book) VWAP(side string, volume Decimal) (Decimal, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if volume.Sign() <= 0 {
+		return "", false
+	}
+
+	remaining := volume
+	notional := Decimal("0")
+	for _, level := range b.levels(side) {
+		if !level.Size.LessThan(remaining) {
+			notional = notional.Add(level.Price.Mul(remaining))
+			return notional.Div(volume), true
+		}
+		notional = notional.Add(level.Price.Mul(level.Size))
+		remaining = remaining.Sub(level.Size)
+	}
+	return "", false
+}
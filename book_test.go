@@ -0,0 +1,121 @@
+package upbit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+func TestBook(t *testing.T) {
+	book, err := upbit.NewBook(&upbit.Orderbook{
+		Market:    upbit.KRW_BTC,
+		Timestamp: 1,
+		OrderbookUnits: []upbit.OrderbookUnit{
+			{AskPrice: "101", BidPrice: "100", AskSize: "1", BidSize: "2"},
+			{AskPrice: "102", BidPrice: "99", AskSize: "2", BidSize: "1"},
+			{AskPrice: "103", BidPrice: "98", AskSize: "3", BidSize: "4"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ask, _ := book.BestAsk(); ask.Price != "101" {
+		t.Errorf("BestAsk = %v", ask)
+	}
+	if bid, _ := book.BestBid(); bid.Price != "100" {
+		t.Errorf("BestBid = %v", bid)
+	}
+	if spread, _ := book.Spread(); spread != "1" {
+		t.Errorf("Spread = %v", spread)
+	}
+	if depth := book.DepthAt(upbit.SideBid, "102"); !depth.Equal("3") {
+		t.Errorf("DepthAt(bid, 102) = %v", depth)
+	}
+	if depth := book.DepthAt(upbit.SideAsk, "99"); !depth.Equal("3") {
+		t.Errorf("DepthAt(ask, 99) = %v", depth)
+	}
+
+	// 101*1 + 102*2 = 305, then 3 of 103 for the remaining 309.
+	volume, worst, ok := book.VolumeForNotional(upbit.SideBid, "614")
+	if !ok || !volume.Equal("6") || worst != "103" {
+		t.Errorf("VolumeForNotional = %v, %v, %v", volume, worst, ok)
+	}
+	if _, _, ok := book.VolumeForNotional(upbit.SideBid, "10000"); ok {
+		t.Error("VolumeForNotional beyond depth is ok")
+	}
+
+	// (100*2 + 99*1) / 3
+	vwap, ok := book.VWAP(upbit.SideAsk, "3")
+	if !ok || !vwap.Equal("99.6666666666666667") {
+		t.Errorf("VWAP = %v, %v", vwap, ok)
+	}
+
+	if err := book.Update(&upbit.Orderbook{Code: upbit.KRW_ETH}); err == nil {
+		t.Error("Update with another market succeeded")
+	}
+	invalid := &upbit.Orderbook{
+		Market:         upbit.KRW_BTC,
+		Timestamp:      2,
+		OrderbookUnits: []upbit.OrderbookUnit{{AskPrice: "x", AskSize: "1"}},
+	}
+	if err := book.Update(invalid); err == nil {
+		t.Error("Update with an invalid price succeeded")
+	}
+
+	book.Update(&upbit.Orderbook{
+		Code:      upbit.KRW_BTC,
+		Timestamp: 2,
+		OrderbookUnits: []upbit.OrderbookUnit{
+			{AskPrice: "105", BidPrice: "104", AskSize: "1", BidSize: "1"},
+		},
+	})
+	if mid, _ := book.MidPrice(); !mid.Equal("104.5") {
+		t.Errorf("MidPrice after update = %v", mid)
+	}
+}
+
+func TestOrderbookMarket(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/orderbook" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("markets") == "KRW-XYZ" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`[{"market":"KRW-BTC","timestamp":1,"total_ask_size":3,"total_bid_size":3,` +
+			`"orderbook_units":[{"ask_price":101,"bid_price":100,"ask_size":1,"bid_size":2},{"ask_price":102,"bid_price":99,"ask_size":2,"bid_size":1}]}]`))
+	}))
+	defer srv.Close()
+
+	client, err := upbit.NewClient(nil, &upbit.ClientOptions{ServerURL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	ob, _, err := client.Quotations.OrderbookMarket(ctx, upbit.KRW_BTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	book, err := upbit.NewBook(ob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mid, ok := book.MidPrice(); !ok || !mid.Equal("100.5") {
+		t.Errorf("MidPrice = %v, %v", mid, ok)
+	}
+
+	if _, _, err := client.Quotations.Orderbook(ctx, nil); !errors.Is(err, upbit.ErrInvalidArguments) {
+		t.Errorf("no markets err = %v, want %v", err, upbit.ErrInvalidArguments)
+	}
+	if _, _, err := client.Quotations.OrderbookMarket(ctx, "KRW-XYZ"); !errors.Is(err, upbit.ErrMarketNotFound) {
+		t.Errorf("empty response err = %v, want %v", err, upbit.ErrMarketNotFound)
+	}
+}
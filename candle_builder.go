@@ -0,0 +1,219 @@
+package upbit
+
+import (
+	"fmt"
+	"time"
+)
+
+var kst = time.FixedZone("KST", 9*60*60)
+
+// CandleBuilder aggregates trades into candles of any period, e.g. 5 seconds
+// or 2 hours. Like Upbit, it makes no candle for a period without trades.
+//
+// Candles are aligned in UTC. Periods shorter than a day restart at 00:00 UTC
+// every day, so 7-minute candles start at 00:00, 00:07, ... 23:48 and the day
+// ends with a 5-minute candle at 23:55. Periods of a day or longer count from
+// the Unix epoch: a 24-hour candle matches CandleInterval.Truncate(Day), but a
+// 7-day one starts on Thursday, not on Monday like Week, and no period gives
+// calendar months like Month.
+//
+// Trades must be added oldest first; trades before the candle in progress
+// are ignored. TicksIter yields ticks newest first, so collect and reverse
+// them before adding.
+type CandleBuilder struct {
+	market string
+	period time.Duration
+
+	current   *Candle
+	start     time.Time
+	prevClose Decimal
+}
+
+// NewCandleBuilder returns a CandleBuilder of market, or ErrInvalidArguments
+// if period is not positive.
+func NewCandleBuilder(market string, period time.Duration) (*CandleBuilder, error) {
+	if err := checkCandlePeriod(period); err != nil {
+		return nil, err
+	}
+	return &CandleBuilder{market: market, period: period}, nil
+}
+
+func checkCandlePeriod(period time.Duration) error {
+	if period <= 0 {
+		return fmt.Errorf("%w: candle period %v", ErrInvalidArguments, period)
+	}
+	return nil
+}
+
+// Add adds a trade of volume at price made at at, and returns the candle it
+// completed, if any.
+func (b *CandleBuilder) Add(price, volume Decimal, at time.Time) *Candle {
+	start := truncateCandle(at, b.period)
+	if b.current != nil && start.Before(b.start) {
+		return nil
+	}
+
+	var done *Candle
+	if b.current != nil && start.After(b.start) {
+		done = b.Flush()
+	}
+
+	if b.current == nil {
+		b.current = newCandle(b.market, start, b.prevClose)
+		b.start = start
+		b.current.OpeningPrice = price
+		b.current.HighPrice = price
+		b.current.LowPrice = price
+	}
+
+	c := b.current
+	if price.GreaterThan(c.HighPrice) {
+		c.HighPrice = price
+	}
+	if price.LessThan(c.LowPrice) {
+		c.LowPrice = price
+	}
+	c.TradePrice = price
+	c.Timestamp = at.UnixNano() / int64(time.Millisecond)
+	c.CandleAccTradePrice = c.CandleAccTradePrice.Add(price.Mul(volume))
+	c.CandleAccTradeVolume = c.CandleAccTradeVolume.Add(volume)
+	setChange(c)
+	return done
+}
+
+// AddTrade adds a trade of the WebSocket trade stream.
+func (b *CandleBuilder) AddTrade(t *Trade) *Candle {
+	return b.Add(t.TradePrice, t.TradeVolume, msTime(t.TradeTimestamp))
+}
+
+// AddTick adds a trade of QuotationService.Ticks.
+func (b *CandleBuilder) AddTick(t *TradeTick) *Candle {
+	return b.Add(t.TradePrice, t.TradeVolume, msTime(t.Timestamp))
+}
+
+// Current returns a copy of the candle in progress, or nil.
+func (b *CandleBuilder) Current() *Candle {
+	if b.current == nil {
+		return nil
+	}
+	c := *b.current
+	return &c
+}
+
+// Flush completes and returns the candle in progress, or nil. Call it when
+// the period of the candle in progress has passed without further trades.
+func (b *CandleBuilder) Flush() *Candle {
+	c := b.current
+	if c != nil {
+		b.prevClose = c.TradePrice
+		b.current = nil
+	}
+	return c
+}
+
+// truncateCandle returns the start of the candle of period t falls in.
+// Periods shorter than a day restart at 00:00 UTC every day, like Upbit's
+// minute candles, so 7-minute candles start at 00:00, 00:07, ... 23:48 and
+// the day ends with a 5-minute candle at 23:55. Longer periods count from the
+// Unix epoch.
+func truncateCandle(t time.Time, period time.Duration) time.Time {
+	t = t.UTC()
+	origin := time.Unix(0, 0).UTC()
+	if period < 24*time.Hour {
+		origin = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return origin.Add(t.Sub(origin) / period * period)
+}
+
+func msTime(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+func newCandle(market string, start time.Time, prevClose Decimal) *Candle {
+	return &Candle{
+		Market:               market,
+		CandleDateTimeUtc:    start.UTC().Format(candleTimeLayout),
+		CandleDateTimeKst:    start.In(kst).Format(candleTimeLayout),
+		CandleAccTradePrice:  "0",
+		CandleAccTradeVolume: "0",
+		PrevClosingPrice:     prevClose,
+	}
+}
+
+// setChange sets ChangePrice and ChangeRate of c against its
+// PrevClosingPrice, as Upbit does for day candles.
+func setChange(c *Candle) {
+	if c.PrevClosingPrice.Sign() <= 0 {
+		return
+	}
+	c.ChangePrice = c.TradePrice.Sub(c.PrevClosingPrice)
+	c.ChangeRate = c.ChangePrice.Div(c.PrevClosingPrice)
+}
+
+// Resample folds candles into candles of period, such as 1-minute candles
+// into 7-minute or 12-hour ones, aligned like those of CandleBuilder.
+// period should be a multiple of the period of candles. candles may be newest
+// first, as returned by CandleService, or oldest first; the result is in the
+// same order.
+//
+// Prices and accumulated trade price and volume are combined as Upbit does.
+// PrevClosingPrice is the close of the previous resampled candle, or the
+// PrevClosingPrice of the first candle, and ChangePrice and ChangeRate are
+// relative to it.
+func Resample(candles []*Candle, period time.Duration) ([]*Candle, error) {
+	if err := checkCandlePeriod(period); err != nil {
+		return nil, err
+	}
+	reversed := len(candles) > 1 && candles[0].CandleDateTimeUtc > candles[len(candles)-1].CandleDateTimeUtc
+
+	var (
+		resampled []*Candle
+		current   *Candle
+		start     time.Time
+	)
+	for j := range candles {
+		i := j
+		if reversed {
+			i = len(candles) - 1 - j
+		}
+		c := candles[i]
+
+		at, err := candleTime(c)
+		if err != nil {
+			return nil, err
+		}
+		at = truncateCandle(at, period)
+
+		if current == nil || !at.Equal(start) {
+			prevClose := c.PrevClosingPrice
+			if current != nil {
+				prevClose = current.TradePrice
+			}
+			current = newCandle(c.Market, at, prevClose)
+			current.OpeningPrice = c.OpeningPrice
+			current.HighPrice = c.HighPrice
+			current.LowPrice = c.LowPrice
+			start = at
+			resampled = append(resampled, current)
+		}
+
+		if c.HighPrice.GreaterThan(current.HighPrice) {
+			current.HighPrice = c.HighPrice
+		}
+		if c.LowPrice.LessThan(current.LowPrice) {
+			current.LowPrice = c.LowPrice
+		}
+		current.TradePrice = c.TradePrice
+		current.Timestamp = c.Timestamp
+		current.CandleAccTradePrice = current.CandleAccTradePrice.Add(c.CandleAccTradePrice)
+		current.CandleAccTradeVolume = current.CandleAccTradeVolume.Add(c.CandleAccTradeVolume)
+		setChange(current)
+	}
+
+	if reversed {
+		for i, j := 0, len(resampled)-1; i < j; i, j = i+1, j-1 {
+			resampled[i], resampled[j] = resampled[j], resampled[i]
+		}
+	}
+	return resampled, nil
+}
@@ -0,0 +1,103 @@
+package upbit_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+func TestCandleBuilder(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	b, err := upbit.NewCandleBuilder(upbit.KRW_BTC, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trades := []struct {
+		offset        time.Duration
+		price, volume upbit.Decimal
+	}{
+		{0, "100", "1"},
+		{time.Second, "105", "2"},
+		{2 * time.Second, "95", "1"},
+		{4 * time.Second, "102", "1"},
+		{12 * time.Second, "110", "1"}, // completes the first candle, skips 5s
+		{3 * time.Second, "1", "1"},    // late, ignored
+		{14 * time.Second, "111", "1"},
+	}
+	var done []*upbit.Candle
+	for _, tr := range trades {
+		if c := b.Add(tr.price, tr.volume, start.Add(tr.offset)); c != nil {
+			done = append(done, c)
+		}
+	}
+	done = append(done, b.Flush())
+
+	if len(done) != 2 {
+		t.Fatalf("%d candles, want 2", len(done))
+	}
+	first, second := done[0], done[1]
+	if first.CandleDateTimeUtc != "2021-01-01T00:00:00" || first.CandleDateTimeKst != "2021-01-01T09:00:00" ||
+		first.OpeningPrice != "100" || first.HighPrice != "105" || first.LowPrice != "95" || first.TradePrice != "102" ||
+		!first.CandleAccTradeVolume.Equal("5") || !first.CandleAccTradePrice.Equal("507") {
+		t.Errorf("first = %+v", first)
+	}
+	if second.CandleDateTimeUtc != "2021-01-01T00:00:10" || second.OpeningPrice != "110" || second.TradePrice != "111" ||
+		second.PrevClosingPrice != "102" || !second.ChangePrice.Equal("9") || !second.ChangeRate.Equal(upbit.Decimal("9").Div("102")) {
+		t.Errorf("second = %+v", second)
+	}
+	if b.Current() != nil {
+		t.Error("candle in progress after Flush")
+	}
+}
+
+func TestResample(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var candles []*upbit.Candle
+	for i := 0; i < 10; i++ {
+		price := upbit.DecimalFromInt(int64(100 + i))
+		// Newest first, as returned by CandleService.
+		candles = append([]*upbit.Candle{{
+			Market:               upbit.KRW_BTC,
+			CandleDateTimeUtc:    start.Add(time.Duration(i) * time.Minute).Format("2006-01-02T15:04:05"),
+			OpeningPrice:         price,
+			HighPrice:            price.Add("5"),
+			LowPrice:             price.Sub("5"),
+			TradePrice:           price.Add("1"),
+			CandleAccTradePrice:  price,
+			CandleAccTradeVolume: "1",
+			Timestamp:            int64(i),
+		}}, candles...)
+	}
+
+	resampled, err := upbit.Resample(candles, 7*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resampled) != 2 {
+		t.Fatalf("%d candles, want 2", len(resampled))
+	}
+
+	newest, oldest := resampled[0], resampled[1]
+	if oldest.CandleDateTimeUtc != "2021-01-01T00:00:00" || oldest.OpeningPrice != "100" ||
+		oldest.HighPrice != "111" || oldest.LowPrice != "95" || oldest.TradePrice != "107" ||
+		!oldest.CandleAccTradeVolume.Equal("7") || !oldest.CandleAccTradePrice.Equal("721") {
+		t.Errorf("oldest = %+v", oldest)
+	}
+	if newest.CandleDateTimeUtc != "2021-01-01T00:07:00" || newest.OpeningPrice != "107" || newest.TradePrice != "110" ||
+		newest.PrevClosingPrice != "107" || !newest.ChangePrice.Equal("3") || newest.Timestamp != 9 {
+		t.Errorf("newest = %+v", newest)
+	}
+}
+
+func TestCandlePeriodInvalid(t *testing.T) {
+	if _, err := upbit.NewCandleBuilder(upbit.KRW_BTC, 0); !errors.Is(err, upbit.ErrInvalidArguments) {
+		t.Errorf("NewCandleBuilder err = %v, want %v", err, upbit.ErrInvalidArguments)
+	}
+	candles := []*upbit.Candle{{Market: upbit.KRW_BTC, CandleDateTimeUtc: "2021-01-01T00:00:00"}}
+	if _, err := upbit.Resample(candles, -time.Minute); !errors.Is(err, upbit.ErrInvalidArguments) {
+		t.Errorf("Resample err = %v, want %v", err, upbit.ErrInvalidArguments)
+	}
+}
@@ -0,0 +1,322 @@
+package upbit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxCandlesCount is the largest count accepted by the candle API.
+const maxCandlesCount = 200
+
+// candleTimeLayout is the layout of CandleDateTimeUtc and of the to parameter,
+// which the API reads as UTC when suffixed with Z.
+const candleTimeLayout = "2006-01-02T15:04:05"
+
+// CandleInterval is the period of a candle. Its value is the short form
+// accepted by ParseCandleInterval, so it reads well in flags and config
+// files.
+type CandleInterval string
+
+const (
+	Minute1   CandleInterval = "1m"
+	Minute3   CandleInterval = "3m"
+	Minute5   CandleInterval = "5m"
+	Minute10  CandleInterval = "10m"
+	Minute15  CandleInterval = "15m"
+	Minute30  CandleInterval = "30m"
+	Minute60  CandleInterval = "60m"
+	Minute240 CandleInterval = "240m"
+	Day       CandleInterval = "1d"
+	Week      CandleInterval = "1w"
+	Month     CandleInterval = "1M"
+)
+
+var candlePaths = map[CandleInterval]string{
+	Minute1:   "minutes/1",
+	Minute3:   "minutes/3",
+	Minute5:   "minutes/5",
+	Minute10:  "minutes/10",
+	Minute15:  "minutes/15",
+	Minute30:  "minutes/30",
+	Minute60:  "minutes/60",
+	Minute240: "minutes/240",
+	Day:       "days",
+	Week:      "weeks",
+	Month:     "months",
+}
+
+// CandleIntervals lists the intervals served by the candle API, shortest
+// first.
+var CandleIntervals = []CandleInterval{
+	Minute1, Minute3, Minute5, Minute10, Minute15, Minute30, Minute60, Minute240, Day, Week, Month,
+}
+
+var candleIntervalAliases = map[string]CandleInterval{
+	"1h":    Minute60,
+	"4h":    Minute240,
+	"d":     Day,
+	"day":   Day,
+	"w":     Week,
+	"week":  Week,
+	"month": Month,
+}
+
+// ParseCandleInterval parses the short forms of the CandleInterval constants,
+// such as "15m", "1d", "1w" and "1M", as well as "1h", "4h", "day", "week"
+// and "month".
+func ParseCandleInterval(s string) (CandleInterval, error) {
+	if i := CandleInterval(s); i.Valid() {
+		return i, nil
+	}
+	if i, ok := candleIntervalAliases[strings.ToLower(s)]; ok {
+		return i, nil
+	}
+	return "", fmt.Errorf("upbit: invalid candle interval %q", s)
+}
+
+// MinuteInterval returns the interval of minute candles of unit, which must
+// be one of 1, 3, 5, 10, 15, 30, 60 and 240.
+func MinuteInterval(unit int) (CandleInterval, error) {
+	i := CandleInterval(fmt.Sprintf("%dm", unit))
+	if !i.Valid() {
+		return "", fmt.Errorf("upbit: invalid minute unit %d", unit)
+	}
+	return i, nil
+}
+
+func (i CandleInterval) Valid() bool {
+	_, ok := candlePaths[i]
+	return ok
+}
+
+func (i CandleInterval) String() string {
+	return string(i)
+}
+
+// Set implements flag.Value.
+func (i *CandleInterval) Set(s string) error {
+	v, err := ParseCandleInterval(s)
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+func (i *CandleInterval) UnmarshalText(b []byte) error {
+	return i.Set(string(b))
+}
+
+// Duration returns the length of a candle. Months are counted as 30 days;
+// use Truncate and Next for exact boundaries.
+func (i CandleInterval) Duration() time.Duration {
+	switch i {
+	case Day:
+		return 24 * time.Hour
+	case Week:
+		return 7 * 24 * time.Hour
+	case Month:
+		return 30 * 24 * time.Hour
+	}
+
+	var minutes int
+	if _, err := fmt.Sscanf(string(i), "%dm", &minutes); err != nil || !i.Valid() {
+		return 0
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// Truncate returns the start of the candle t falls in, in UTC. Candles are
+// aligned in UTC: days start at 00:00 UTC (09:00 KST), weeks on Monday and
+// months on the first day.
+func (i CandleInterval) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	switch i {
+	case Day:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	case Week:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case Month:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return t.Truncate(i.Duration())
+}
+
+// Next returns the start of the candle following the one starting at start.
+func (i CandleInterval) Next(start time.Time) time.Time {
+	if i == Month {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.Add(i.Duration())
+}
+
+func (i CandleInterval) path() (string, error) {
+	path, ok := candlePaths[i]
+	if !ok {
+		return "", fmt.Errorf("upbit: invalid candle interval %q", string(i))
+	}
+	return path, nil
+}
+
+// Candles returns candles of market, newest first.
+func (s *CandleService) Candles(ctx context.Context, market string, interval CandleInterval, opts *CandleListOptions) ([]*Candle, *http.Response, error) {
+	path, err := interval.path()
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.candle(ctx, path, market, opts)
+}
+
+func candleTime(c *Candle) (time.Time, error) {
+	return time.Parse(candleTimeLayout, c.CandleDateTimeUtc)
+}
+
+// Range returns the candles of market starting in [from, to), oldest first.
+// It pages backwards from to by 200 candles, the most the API returns per
+// call, so a long range takes many requests, each waiting for the
+// client's rate limiter. A zero to means now.
+func (s *CandleService) Range(ctx context.Context, market string, interval CandleInterval, from, to time.Time) ([]*Candle, error) {
+	it := s.RangeIter(market, interval, from, to)
+
+	var candles []*Candle
+	for it.Next(ctx) {
+		candles = append(candles, it.Candle())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(candles)-1; i < j; i, j = i+1, j-1 {
+		candles[i], candles[j] = candles[j], candles[i]
+	}
+	return candles, nil
+}
+
+// CandlesIter walks the candles of a range backwards, newest first, one page
+// at a time.
+//
+//	it := client.Candles.RangeIter(upbit.KRW_BTC, upbit.Minute1, from, to)
+//	for it.Next(ctx) {
+//		candle := it.Candle()
+//	}
+//	err := it.Err()
+type CandlesIter struct {
+	service *CandleService
+	market  string
+	path    string
+	from    time.Time
+	end     time.Time
+	to      time.Time // of the next page
+
+	page   []*Candle
+	candle *Candle
+	// seen is the start of the last candle returned, to drop the candle a
+	// page boundary may repeat.
+	seen string
+	last bool
+	err  error
+}
+
+// RangeIter returns an iterator over the candles of Range, newest first.
+func (s *CandleService) RangeIter(market string, interval CandleInterval, from, to time.Time) *CandlesIter {
+	it := &CandlesIter{
+		service: s,
+		market:  market,
+		from:    from.UTC(),
+		to:      to.UTC(),
+	}
+	if to.IsZero() {
+		it.to = time.Now().UTC()
+	}
+	it.end = it.to
+
+	path, err := interval.path()
+	if err != nil {
+		it.err = err
+	}
+	it.path = path
+	return it
+}
+
+// Next advances to the next older candle, fetching a page when needed. It
+// returns false at the end of the range or on error.
+func (it *CandlesIter) Next(ctx context.Context) bool {
+	for it.err == nil {
+		if len(it.page) == 0 {
+			if it.last || !it.to.After(it.from) {
+				return false
+			}
+			if !it.fetch(ctx) {
+				return false
+			}
+			continue
+		}
+
+		candle := it.page[0]
+		it.page = it.page[1:]
+		if candle.CandleDateTimeUtc == it.seen {
+			continue
+		}
+
+		start, err := candleTime(candle)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if !start.Before(it.end) {
+			continue
+		}
+		if start.Before(it.from) {
+			it.page = nil
+			it.last = true
+			return false
+		}
+
+		it.seen = candle.CandleDateTimeUtc
+		it.candle = candle
+		return true
+	}
+	return false
+}
+
+func (it *CandlesIter) fetch(ctx context.Context) bool {
+	page, _, err := it.service.candle(ctx, it.path, it.market, &CandleListOptions{
+		To:    it.to.Format(candleTimeLayout) + "Z",
+		Count: maxCandlesCount,
+	})
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if len(page) < maxCandlesCount {
+		it.last = true
+	}
+	if len(page) == 0 {
+		return false
+	}
+
+	oldest, err := candleTime(page[len(page)-1])
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if !oldest.Before(it.to) {
+		// The server ignored to; stop instead of asking for the same page.
+		it.last = true
+	}
+	it.to = oldest
+	it.page = page
+	return true
+}
+
+func (it *CandlesIter) Candle() *Candle {
+	return it.candle
+}
+
+func (it *CandlesIter) Err() error {
+	return it.err
+}
@@ -0,0 +1,106 @@
+package upbit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+	"github.com/investing-kr/go-upbit/upbittest"
+)
+
+func TestCandleRange(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+	srv.AddMarket(upbit.MarketCode{Market: upbit.KRW_BTC})
+
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 450; i++ {
+		srv.AddCandles(upbit.KRW_BTC, "minutes/1", &upbit.Candle{
+			Market:            upbit.KRW_BTC,
+			CandleDateTimeUtc: start.Add(time.Duration(i) * time.Minute).Format("2006-01-02T15:04:05"),
+		})
+	}
+
+	client, err := srv.Client(&upbit.ClientOptions{RateLimitPolicy: upbit.RateLimitDisabled})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	from, to := start.Add(10*time.Minute), start.Add(430*time.Minute)
+	candles, err := client.Candles.Range(ctx, upbit.KRW_BTC, upbit.Minute1, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 420 {
+		t.Fatalf("%d candles, want 420", len(candles))
+	}
+	for i, c := range candles {
+		want := from.Add(time.Duration(i) * time.Minute).Format("2006-01-02T15:04:05")
+		if c.CandleDateTimeUtc != want {
+			t.Fatalf("candle %d at %s, want %s", i, c.CandleDateTimeUtc, want)
+		}
+	}
+
+	candles, err = client.Candles.Range(ctx, upbit.KRW_BTC, upbit.Minute1, time.Time{}, start.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 60 {
+		t.Errorf("%d candles from the first, want 60", len(candles))
+	}
+
+	if _, err := client.Candles.Range(ctx, upbit.KRW_BTC, "2m", from, to); err == nil {
+		t.Error("invalid interval accepted")
+	}
+}
+
+func TestCandleInterval(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want upbit.CandleInterval
+	}{
+		{"15m", upbit.Minute15},
+		{"4h", upbit.Minute240},
+		{"1d", upbit.Day},
+		{"Week", upbit.Week},
+		{"1M", upbit.Month},
+	} {
+		got, err := upbit.ParseCandleInterval(tc.s)
+		if err != nil || got != tc.want {
+			t.Errorf("ParseCandleInterval(%q) = %q, %v, want %q", tc.s, got, err, tc.want)
+		}
+	}
+	if _, err := upbit.ParseCandleInterval("2m"); err == nil {
+		t.Error("2m parsed")
+	}
+
+	client, err := upbit.NewClient(nil, &upbit.ClientOptions{ServerURL: "http://127.0.0.1:1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Candles.CandleMinutes(context.Background(), upbit.KRW_BTC, 2, nil); err == nil {
+		t.Error("minute unit 2 accepted")
+	}
+
+	at := time.Date(2021, 3, 17, 14, 23, 45, 0, time.FixedZone("KST", 9*3600))
+	for _, tc := range []struct {
+		interval    upbit.CandleInterval
+		start, next string
+	}{
+		{upbit.Minute15, "2021-03-17T05:15:00Z", "2021-03-17T05:30:00Z"},
+		{upbit.Minute240, "2021-03-17T04:00:00Z", "2021-03-17T08:00:00Z"},
+		{upbit.Day, "2021-03-17T00:00:00Z", "2021-03-18T00:00:00Z"},
+		{upbit.Week, "2021-03-15T00:00:00Z", "2021-03-22T00:00:00Z"},
+		{upbit.Month, "2021-03-01T00:00:00Z", "2021-04-01T00:00:00Z"},
+	} {
+		start := tc.interval.Truncate(at)
+		if got := start.Format(time.RFC3339); got != tc.start {
+			t.Errorf("%s: Truncate = %s, want %s", tc.interval, got, tc.start)
+		}
+		if got := tc.interval.Next(start).Format(time.RFC3339); got != tc.next {
+			t.Errorf("%s: Next = %s, want %s", tc.interval, got, tc.next)
+		}
+	}
+}
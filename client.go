@@ -6,7 +6,6 @@ import (
 	"crypto/sha512"
 	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -14,6 +13,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/google/uuid"
@@ -29,14 +30,42 @@ const (
 type ClientOptions struct {
 	AccessKey string
 	SecretKey string
-	ServerURL string
-	Debug     bool
+
+	// Region selects the exchange, RegionKR by default. ServerURL and
+	// WebsocketURL override the URLs of the region.
+	Region       Region
+	ServerURL    string
+	WebsocketURL string
+	Debug        bool
+
+	// DryRun logs every request as Debug does, as a curl command carrying
+	// its signature, and returns ErrDryRun instead of sending it.
+	DryRun bool
+
+	// RateLimitPolicy decides what happens when a request would exceed the
+	// budget of its Remaining-Req group. The default is RateLimitBlock.
+	RateLimitPolicy RateLimitPolicy
+
+	// RetryPolicy enables retries of failed requests. The default nil makes
+	// exactly one attempt.
+	RetryPolicy RetryPolicy
+
+	// PriceUnitPolicy checks the price of limit orders against the price
+	// unit of the market. The default is PriceUnitIgnore.
+	PriceUnitPolicy PriceUnitPolicy
+
+	// ValidateOrders rejects orders locally that the market's Chance does
+	// not allow. Chances are cached per market for ChanceTTL, 10 seconds by
+	// default.
+	ValidateOrders bool
+	ChanceTTL      time.Duration
 }
 
 func ClientOptionsFromEnv() *ClientOptions {
 	return &ClientOptions{
 		SecretKey: os.Getenv("UPBIT_OPEN_API_SECRET_KEY"),
 		ServerURL: os.Getenv("UPBIT_OPEN_API_SERVER_URL"),
+		Region:    Region(os.Getenv("UPBIT_OPEN_API_REGION")),
 		AccessKey: os.Getenv("UPBIT_OPEN_API_ACCESS_KEY"),
 		Debug:     false,
 	}
@@ -47,20 +76,30 @@ type service struct {
 }
 
 type Client struct {
-	httpClient *http.Client
-	baseURL    *url.URL
-	common     service
+	httpClient   *http.Client
+	baseURL      *url.URL
+	websocketURL *url.URL
+	region       Region
+	common       service
+	limiter      *rateLimiter
+	retryPolicy  RetryPolicy
+	priceUnit    PriceUnitPolicy
+	validate     bool
+	chances      *chanceCache
 
 	debug     bool
+	dryRun    bool
 	accessKey string
 	secretKey string
 
-	Accounts  *AccountService
-	Orders    *OrderService
-	Withdraws *WithdrawService
-	Deposits  *DepositService
-	Markets   *MarketService
-	Candles   *CandleService
+	Accounts   *AccountService
+	Orders     *OrderService
+	Withdraws  *WithdrawService
+	Deposits   *DepositService
+	Markets    *MarketService
+	Candles    *CandleService
+	Quotations *QuotationService
+	Streams    *StreamService
 }
 
 func (c *Client) Debug() *Client {
@@ -70,16 +109,27 @@ func (c *Client) Debug() *Client {
 }
 
 type (
-	AccountService  service
-	MarketService   service
-	OrderService    service
-	WithdrawService service
-	DepositService  service
-	CandleService   service
+	AccountService   service
+	MarketService    service
+	OrderService     service
+	WithdrawService  service
+	DepositService   service
+	CandleService    service
+	QuotationService service
+	StreamService    service
 )
 
 func NewClient(httpClient *http.Client, opt *ClientOptions) (*Client, error) {
-	serverURL := UpbitKR
+	region := opt.Region
+	if region == "" {
+		region = RegionKR
+	}
+	profile, err := region.profile()
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL := profile.serverURL
 	if opt.ServerURL != "" {
 		serverURL = opt.ServerURL
 	}
@@ -89,16 +139,29 @@ func NewClient(httpClient *http.Client, opt *ClientOptions) (*Client, error) {
 		return nil, err
 	}
 
+	websocketURL, err := parseWebsocketURL(baseURL, opt.WebsocketURL)
+	if err != nil {
+		return nil, err
+	}
+
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
 
 	c := &Client{
-		accessKey:  opt.AccessKey,
-		secretKey:  opt.SecretKey,
-		baseURL:    baseURL,
-		httpClient: httpClient,
-		debug:      opt.Debug,
+		accessKey:    opt.AccessKey,
+		secretKey:    opt.SecretKey,
+		baseURL:      baseURL,
+		websocketURL: websocketURL,
+		region:       region,
+		httpClient:   httpClient,
+		limiter:      newRateLimiter(opt.RateLimitPolicy),
+		retryPolicy:  opt.RetryPolicy,
+		priceUnit:    opt.PriceUnitPolicy,
+		validate:     opt.ValidateOrders,
+		chances:      newChanceCache(opt.ChanceTTL),
+		debug:        opt.Debug,
+		dryRun:       opt.DryRun,
 	}
 
 	c.common.client = c
@@ -108,6 +171,8 @@ func NewClient(httpClient *http.Client, opt *ClientOptions) (*Client, error) {
 	c.Deposits = (*DepositService)(&c.common)
 	c.Markets = (*MarketService)(&c.common)
 	c.Candles = (*CandleService)(&c.common)
+	c.Quotations = (*QuotationService)(&c.common)
+	c.Streams = (*StreamService)(&c.common)
 	return c, nil
 }
 
@@ -116,13 +181,41 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*htt
 		ctx = context.TODO()
 	}
 
-	if c.debug {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.do(ctx, req, v)
+		if err == nil || c.retryPolicy == nil || req.Method != http.MethodGet {
+			return resp, err
+		}
+
+		wait, ok := c.retryPolicy.Retry(attempt, resp, err)
+		if !ok {
+			return resp, err
+		}
+		if serr := sleepContext(ctx, wait); serr != nil {
+			return resp, err
+		}
+		if serr := c.resign(req); serr != nil {
+			return resp, serr
+		}
+	}
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
+	if c.debug || c.dryRun {
 		cmd, err := http2curl.GetCurlCommand(req)
 		if err != nil {
 			return nil, err
 		}
 		log.Println(cmd)
 	}
+	if c.dryRun {
+		return nil, ErrDryRun
+	}
+
+	group := rateLimitGroup(req.Method, strings.TrimPrefix(req.URL.Path, c.baseURL.Path))
+	if err := c.limiter.wait(ctx, group); err != nil {
+		return nil, err
+	}
 
 	req = req.WithContext(ctx)
 	resp, err := c.httpClient.Do(req)
@@ -136,6 +229,13 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*htt
 	}
 	defer resp.Body.Close()
 
+	if rl, _ := ParseRateLimit(resp); rl != nil {
+		c.limiter.update(rl)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		c.limiter.exhausted(group)
+	}
+
 	err = CheckResponse(resp)
 	if err != nil {
 		return resp, err
@@ -177,6 +277,7 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*htt
 var (
 	ErrNotImplemented   = fmt.Errorf("upbit: not implemented")
 	ErrInvalidArguments = fmt.Errorf("upbit: invalid arguments")
+	ErrDryRun           = fmt.Errorf("upbit: request not sent in dry run")
 )
 
 // API document doesn't specifiy error model. This might change.
@@ -185,12 +286,20 @@ type ErrResponse struct {
 		Message string `json:"message"`
 		Name    string `json:"name"`
 	} `json:"error"`
+
+	StatusCode int    `json:"-"` // zero for websocket errors
+	Body       []byte `json:"-"`
 }
 
 func (e *ErrResponse) Error() string {
+	if e.Detail.Name == "" {
+		return fmt.Sprintf("upbit: %d %s", e.StatusCode, e.Body)
+	}
 	return fmt.Sprintf("upbit: %s, %s", e.Detail.Name, e.Detail.Message)
 }
 
+// CheckResponse returns an *ErrResponse for a non-2xx response. The error
+// matches the sentinel errors of errors.go with errors.Is.
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; 200 <= c && c <= 299 {
 		return nil
@@ -202,14 +311,12 @@ func CheckResponse(r *http.Response) error {
 	}
 
 	errResp := &ErrResponse{}
-	err = json.Unmarshal(body, errResp)
-
-	// If server response with sturctured error
-	if err == nil && errResp.Detail.Name != "" {
-		return errResp
-	}
+	// Not every error is structured, e.g. 429 answers with plain text.
+	json.Unmarshal(body, errResp)
+	errResp.StatusCode = r.StatusCode
+	errResp.Body = body
 
-	return errors.New(string(body))
+	return errResp
 }
 
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
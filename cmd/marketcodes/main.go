@@ -0,0 +1,212 @@
+// Command marketcodes generates the market code constants of package upbit
+// from a /v1/market/all?isDetail=true response, read from a file or fetched
+// from the API:
+//
+//	go run ./cmd/marketcodes -region kr
+//	go run ./cmd/marketcodes -region sg -in testdata/market_all_sg.json
+//
+// go generate in package upbit runs it on the responses recorded in testdata.
+//
+// Constants of the output file that are no longer listed are kept, marked
+// deprecated, so that code using them still compiles.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+type region struct {
+	server string
+	out    string
+	// prefix is prepended to constant names so that the regions can share
+	// package upbit, e.g. SG_BTC_ETH next to BTC_ETH.
+	prefix string
+	name   string
+}
+
+var regions = map[string]region{
+	"kr": {upbit.UpbitKR, "market_codes.go", "", "Upbit KR"},
+	"sg": {upbit.UpbitSG, "market_codes_sg.go", "SG_", "Upbit SG"},
+	"id": {upbit.UpbitID, "market_codes_id.go", "ID_", "Upbit ID"},
+}
+
+func main() {
+	var (
+		regionName = flag.String("region", "kr", "region of the markets: kr, sg or id")
+		in         = flag.String("in", "", "market/all JSON file, - for stdin; fetched from the API if empty")
+		out        = flag.String("out", "", "output file; market_codes.go, market_codes_sg.go or market_codes_id.go by default")
+		pkg        = flag.String("package", "upbit", "package name of the output file")
+	)
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("marketcodes: ")
+
+	r, ok := regions[*regionName]
+	if !ok {
+		log.Fatalf("unknown region %q", *regionName)
+	}
+	if *out == "" {
+		*out = r.out
+	}
+
+	markets, err := readMarkets(*in, r.server)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	previous, err := readConstants(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(*pkg, r, markets, previous)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readMarkets(in, server string) ([]*upbit.MarketCode, error) {
+	if in == "" {
+		client, err := upbit.NewClient(nil, &upbit.ClientOptions{ServerURL: server})
+		if err != nil {
+			return nil, err
+		}
+		markets, _, err := client.Markets.All(context.Background())
+		return markets, err
+	}
+
+	var r io.Reader = os.Stdin
+	if in != "-" {
+		f, err := os.Open(in)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var markets []*upbit.MarketCode
+	if err := json.NewDecoder(r).Decode(&markets); err != nil {
+		return nil, fmt.Errorf("%s: %v", in, err)
+	}
+	return markets, nil
+}
+
+// readConstants returns the string constants declared in filename by name.
+// A missing file has none.
+func readConstants(filename string) (map[string]string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	constants := map[string]string{}
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if i >= len(vs.Values) {
+					continue
+				}
+				lit, ok := vs.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				value, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					return nil, err
+				}
+				constants[name.Name] = value
+			}
+		}
+	}
+	return constants, nil
+}
+
+func constantName(prefix, market string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, market)
+	return prefix + strings.ToUpper(name)
+}
+
+func generate(pkg string, r region, markets []*upbit.MarketCode, previous map[string]string) ([]byte, error) {
+	type constant struct {
+		name, value, comment string
+		delisted             bool
+	}
+
+	var constants []constant
+	listed := map[string]bool{}
+	for _, m := range markets {
+		name := constantName(r.prefix, m.Market)
+		if listed[name] {
+			continue
+		}
+		listed[name] = true
+
+		comment := m.EnglishName
+		if m.Caution() {
+			comment += " (CAUTION)"
+		}
+		constants = append(constants, constant{name: name, value: m.Market, comment: comment})
+	}
+	for name, value := range previous {
+		if !listed[name] {
+			constants = append(constants, constant{name: name, value: value, delisted: true})
+		}
+	}
+	sort.Slice(constants, func(i, j int) bool { return constants[i].name < constants[j].name })
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by go run ./cmd/marketcodes; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	fmt.Fprintf(&b, "// Markets listed on %s when this file was generated. Use\n", r.name)
+	fmt.Fprintf(&b, "// MarketRegistry to follow listings at run time.\n")
+	fmt.Fprintf(&b, "const (\n")
+	for _, c := range constants {
+		if c.delisted {
+			fmt.Fprintf(&b, "\t// Deprecated: %s is no longer listed.\n", c.value)
+			fmt.Fprintf(&b, "\t%s = %q\n", c.name, c.value)
+			continue
+		}
+		fmt.Fprintf(&b, "\t%s = %q", c.name, c.value)
+		if c.comment != "" {
+			fmt.Fprintf(&b, " // %s", c.comment)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, ")\n")
+
+	return format.Source(b.Bytes())
+}
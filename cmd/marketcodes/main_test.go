@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+func TestGenerate(t *testing.T) {
+	markets := []*upbit.MarketCode{
+		{Market: "SGD-BTC", EnglishName: "Bitcoin", MarketWarning: upbit.MarketWarningNone},
+		{Market: "BTC-ETH", EnglishName: "Ethereum", MarketWarning: upbit.MarketWarningCaution},
+	}
+	previous := map[string]string{"SG_SGD_BTC": "SGD-BTC", "SG_BTC_STRAT": "BTC-STRAT"}
+
+	src, err := generate("upbit", regions["sg"], markets, previous)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Ignore gofmt alignment.
+	got := strings.Join(strings.Fields(string(src)), " ")
+	for _, want := range []string{
+		`SG_BTC_ETH = "BTC-ETH" // Ethereum (CAUTION)`,
+		`// Deprecated: BTC-STRAT is no longer listed. SG_BTC_STRAT = "BTC-STRAT"`,
+		`SG_SGD_BTC = "SGD-BTC" // Bitcoin`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in\n%s", want, src)
+		}
+	}
+}
+
+// TestCheckedIn checks that the market code files of package upbit are the
+// output of the generator for the recorded responses in testdata.
+func TestCheckedIn(t *testing.T) {
+	for name, r := range regions {
+		t.Run(name, func(t *testing.T) {
+			markets, err := readMarkets(filepath.Join("testdata", "market_all_"+name+".json"), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join("..", "..", r.out)
+			previous, err := readConstants(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			src, err := generate("upbit", r, markets, previous)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkedIn, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(src, checkedIn) {
+				t.Errorf("%s is not the generator's output; run go generate in package upbit", r.out)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Command upbit-record records the ticker, trade and orderbook streams of
+// Upbit markets to gzip-compressed JSON-lines files, partitioned by market
+// and date, until interrupted:
+//
+//	go run ./cmd/upbit-record -dir data -markets KRW-BTC,KRW-ETH
+//	go run ./cmd/upbit-record -dir data -quote KRW -types trade
+//
+// The recording is read back with recorder.Open and replays in package
+// backtest. The server and region are taken from the environment as by
+// upbit.ClientOptionsFromEnv; no API keys are needed.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+	"github.com/investing-kr/go-upbit/recorder"
+)
+
+// config holds the flags.
+type config struct {
+	dir     string
+	markets []string
+	quote   string
+	types   []string
+	rotate  time.Duration
+	flush   time.Duration
+}
+
+func parseFlags(args []string) (*config, error) {
+	fs := flag.NewFlagSet("upbit-record", flag.ContinueOnError)
+	var (
+		dir     = fs.String("dir", "data", "directory of the recording")
+		markets = fs.String("markets", "", "comma separated markets to record")
+		quote   = fs.String("quote", "", "record all markets of this quote currency, e.g. KRW")
+		types   = fs.String("types", "ticker,trade,orderbook", "comma separated stream types")
+		rotate  = fs.Duration("rotate", 0, "how long a file covers; an hour by default")
+		flush   = fs.Duration("flush", 0, "how often to flush files; 5s by default")
+	)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return &config{
+		dir:     *dir,
+		markets: split(*markets),
+		quote:   strings.ToUpper(*quote),
+		types:   split(*types),
+		rotate:  *rotate,
+		flush:   *flush,
+	}, nil
+}
+
+// options returns the recorder options of the flags for markets.
+func (c *config) options(markets []string) *recorder.Options {
+	return &recorder.Options{
+		Dir:            c.dir,
+		Markets:        markets,
+		Types:          c.types,
+		RotateInterval: c.rotate,
+		FlushInterval:  c.flush,
+		OnStatus:       logStatus,
+	}
+}
+
+func logStatus(status upbit.StreamStatus) {
+	switch s := status.(type) {
+	case *upbit.Disconnected:
+		log.Printf("disconnected: %v", s.Err)
+	case *upbit.Reconnected:
+		log.Printf("reconnected after %v and %d attempts", s.Downtime, s.Attempts)
+		for _, gap := range s.Gaps {
+			log.Printf("gap in %s %s after %d, %d backfilled", gap.Type, gap.Code, gap.LastTimestamp, gap.Backfilled)
+		}
+	}
+}
+
+func main() {
+	cfg, err := parseFlags(os.Args[1:])
+	if err == flag.ErrHelp {
+		return
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+	log.SetFlags(log.LstdFlags)
+	log.SetPrefix("upbit-record: ")
+
+	client, err := upbit.NewClient(nil, upbit.ClientOptionsFromEnv())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		cancel()
+	}()
+
+	codes := cfg.markets
+	if cfg.quote != "" {
+		registry := upbit.NewMarketRegistry(client.Markets)
+		if _, err := registry.Refresh(ctx); err != nil {
+			log.Fatal(err)
+		}
+		for _, m := range registry.ByQuote(cfg.quote) {
+			codes = append(codes, m.Market)
+		}
+	}
+	if len(codes) == 0 {
+		log.Fatal("no markets; use -markets or -quote")
+	}
+
+	rec, err := recorder.New(client, cfg.options(codes))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("recording %d markets to %s", len(codes), cfg.dir)
+	if err := rec.Run(ctx); err != nil && err != context.Canceled {
+		log.Fatal(err)
+	}
+}
+
+func split(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
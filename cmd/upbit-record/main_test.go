@@ -0,0 +1,51 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSplit(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want []string
+	}{
+		{"", nil},
+		{"KRW-BTC", []string{"KRW-BTC"}},
+		{" KRW-BTC, KRW-ETH ,,", []string{"KRW-BTC", "KRW-ETH"}},
+	} {
+		if got := split(tc.s); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("split(%q) = %q, want %q", tc.s, got, tc.want)
+		}
+	}
+}
+
+func TestOptions(t *testing.T) {
+	cfg, err := parseFlags([]string{"-dir", "out", "-markets", "KRW-BTC,KRW-ETH", "-quote", "btc", "-types", "trade", "-rotate", "15m", "-flush", "1s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg.markets, []string{"KRW-BTC", "KRW-ETH"}) || cfg.quote != "BTC" {
+		t.Errorf("markets = %q, quote = %q", cfg.markets, cfg.quote)
+	}
+
+	opts := cfg.options([]string{"KRW-BTC"})
+	if opts.Dir != "out" || !reflect.DeepEqual(opts.Markets, []string{"KRW-BTC"}) || !reflect.DeepEqual(opts.Types, []string{"trade"}) ||
+		opts.RotateInterval != 15*time.Minute || opts.FlushInterval != time.Second || opts.OnStatus == nil {
+		t.Errorf("options = %+v", opts)
+	}
+
+	cfg, err = parseFlags(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts = cfg.options(nil)
+	if opts.Dir != "data" || !reflect.DeepEqual(opts.Types, []string{"ticker", "trade", "orderbook"}) || opts.RotateInterval != 0 || opts.FlushInterval != 0 {
+		t.Errorf("default options = %+v", opts)
+	}
+
+	if _, err := parseFlags([]string{"-rotate", "soon"}); err == nil {
+		t.Error("parseFlags accepted an invalid duration")
+	}
+}
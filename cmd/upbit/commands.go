@@ -0,0 +1,359 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+func runAccounts(ctx context.Context, c *cli, fs *flag.FlagSet, args []string) error {
+	if _, err := parseArgs(fs, args, 0, 0); err != nil {
+		return err
+	}
+	client, err := c.connect()
+	if err != nil {
+		return err
+	}
+
+	accounts, _, err := client.Accounts.Accounts(ctx)
+	if err != nil {
+		return err
+	}
+	return c.print(accounts)
+}
+
+func runMarkets(ctx context.Context, c *cli, fs *flag.FlagSet, args []string) error {
+	quote := fs.String("quote", "", "only markets quoted in this currency, e.g. KRW")
+	caution := fs.Bool("caution", false, "only markets flagged for caution")
+	if _, err := parseArgs(fs, args, 0, 0); err != nil {
+		return err
+	}
+	client, err := c.connect()
+	if err != nil {
+		return err
+	}
+
+	all, _, err := client.Markets.All(ctx)
+	if err != nil {
+		return err
+	}
+	markets := []*upbit.MarketCode{}
+	for _, m := range all {
+		if *quote != "" && !strings.EqualFold(m.Quote(), *quote) || *caution && !m.Caution() {
+			continue
+		}
+		markets = append(markets, m)
+	}
+	return c.print(markets)
+}
+
+func runTicker(ctx context.Context, c *cli, fs *flag.FlagSet, args []string) error {
+	markets, err := parseArgs(fs, args, 1, -1)
+	if err != nil {
+		return err
+	}
+	client, err := c.connect()
+	if err != nil {
+		return err
+	}
+
+	tickers, _, err := client.Candles.Ticker(ctx, markets)
+	if err != nil {
+		return err
+	}
+	return c.print(tickers)
+}
+
+// timeLayouts are the layouts accepted by -from and -to, in UTC unless
+// the time says otherwise.
+var timeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+type timeFlag struct {
+	t time.Time
+}
+
+func (f *timeFlag) String() string {
+	if f.t.IsZero() {
+		return ""
+	}
+	return f.t.Format(time.RFC3339)
+}
+
+func (f *timeFlag) Set(s string) error {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			f.t = t
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid time %q, want e.g. 2021-01-02T15:04:05", s)
+}
+
+func runCandles(ctx context.Context, c *cli, fs *flag.FlagSet, args []string) error {
+	interval := upbit.Minute1
+	var from, to timeFlag
+	fs.Var(&interval, "interval", "candle interval: 1m, 3m, 5m, 10m, 15m, 30m, 1h, 4h, 1d, 1w or 1M")
+	count := fs.Int("count", 20, "number of candles up to to, at most 200")
+	fs.Var(&from, "from", "list all candles from this time, paging as needed")
+	fs.Var(&to, "to", "list candles before this time; now by default")
+	market, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if from.t.IsZero() && (*count < 1 || *count > 200) {
+		return usage(fs, "-count must be between 1 and 200; use -from for more")
+	}
+	client, err := c.connect()
+	if err != nil {
+		return err
+	}
+
+	if !from.t.IsZero() {
+		candles, err := client.Candles.Range(ctx, market[0], interval, from.t, to.t)
+		if err != nil {
+			return err
+		}
+		for i, j := 0, len(candles)-1; i < j; i, j = i+1, j-1 {
+			candles[i], candles[j] = candles[j], candles[i]
+		}
+		return c.print(candles)
+	}
+
+	opts := &upbit.CandleListOptions{Count: *count}
+	if !to.t.IsZero() {
+		opts.To = to.t.UTC().Format("2006-01-02T15:04:05") + "Z"
+	}
+	candles, _, err := client.Candles.Candles(ctx, market[0], interval, opts)
+	if err != nil {
+		return err
+	}
+	return c.print(candles)
+}
+
+// bookRow is a price level of an orderbook, printed a row per level in
+// tables and CSV.
+type bookRow struct {
+	Market   string        `json:"market"`
+	AskSize  upbit.Decimal `json:"ask_size"`
+	AskPrice upbit.Decimal `json:"ask_price"`
+	BidPrice upbit.Decimal `json:"bid_price"`
+	BidSize  upbit.Decimal `json:"bid_size"`
+}
+
+func runOrderbook(ctx context.Context, c *cli, fs *flag.FlagSet, args []string) error {
+	markets, err := parseArgs(fs, args, 1, -1)
+	if err != nil {
+		return err
+	}
+	client, err := c.connect()
+	if err != nil {
+		return err
+	}
+
+	orderbooks, _, err := client.Quotations.Orderbook(ctx, markets)
+	if err != nil {
+		return err
+	}
+	if c.format == formatJSON {
+		return c.print(orderbooks)
+	}
+
+	rows := []*bookRow{}
+	for _, ob := range orderbooks {
+		for _, unit := range ob.OrderbookUnits {
+			rows = append(rows, &bookRow{ob.Market, unit.AskSize, unit.AskPrice, unit.BidPrice, unit.BidSize})
+		}
+	}
+	return c.print(rows)
+}
+
+var orderCommands []*command
+
+func init() {
+	orderCommands = []*command{
+		{"buy", "[-price P] [-volume V] [-type T] [-identifier ID] MARKET", "place a bid: limit with price and volume, price with price only", runOrderPlace},
+		{"sell", "[-price P] [-volume V] [-type T] [-identifier ID] MARKET", "place an ask: limit with price and volume, market with volume only", runOrderPlace},
+		{"cancel", "[-identifier] UUID", "cancel an order", runOrderCancel},
+		{"get", "[-identifier] UUID", "show an order", runOrderGet},
+		{"list", "[-market M] [-state S] [-page N] [-limit N] [-asc]", "list orders, waiting ones by default", runOrderList},
+	}
+}
+
+func runOrder(ctx context.Context, c *cli, fs *flag.FlagSet, args []string) error {
+	// The flags of the order command itself stop at the subcommand, whose
+	// flags come after its name.
+	if err := fs.Parse(args); err == flag.ErrHelp {
+		return err
+	} else if err != nil {
+		return errUsage
+	}
+	if fs.NArg() == 0 {
+		return usage(fs, "missing order command")
+	}
+	for _, cmd := range orderCommands {
+		if cmd.name == fs.Arg(0) {
+			return cmd.run(ctx, c, c.flags("upbit order "+cmd.name, cmd.args), fs.Args()[1:])
+		}
+	}
+	return usage(fs, "unknown order command %q", fs.Arg(0))
+}
+
+func runOrderPlace(ctx context.Context, c *cli, fs *flag.FlagSet, args []string) error {
+	side := upbit.SideBid
+	if strings.HasSuffix(fs.Name(), "sell") {
+		side = upbit.SideAsk
+	}
+	price := fs.String("price", "", "limit price, or the total to spend of a price bid")
+	volume := fs.String("volume", "", "volume to trade")
+	ordType := fs.String("type", "", "limit, price or market; inferred from -price and -volume by default")
+	identifier := fs.String("identifier", "", "client identifier of the order")
+	market, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	if *ordType == "" {
+		switch {
+		case *price != "" && *volume != "":
+			*ordType = upbit.OrdTypeLimit
+		case side == upbit.SideBid && *price != "":
+			*ordType = upbit.OrdTypePrice
+		case side == upbit.SideAsk && *volume != "":
+			*ordType = upbit.OrdTypeMarket
+		default:
+			return usage(fs, "missing -price or -volume")
+		}
+	}
+
+	client, err := c.connect()
+	if err != nil {
+		return err
+	}
+	order, _, err := client.Orders.Order(ctx, &upbit.OrderRequest{
+		Market:     market[0],
+		Side:       side,
+		Volume:     upbit.Decimal(*volume),
+		Price:      upbit.Decimal(*price),
+		OrdType:    *ordType,
+		Identifier: *identifier,
+	})
+	if err != nil {
+		return err
+	}
+	return c.print(order)
+}
+
+func runOrderCancel(ctx context.Context, c *cli, fs *flag.FlagSet, args []string) error {
+	byIdentifier := fs.Bool("identifier", false, "the argument is the client identifier of the order")
+	id, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := c.connect()
+	if err != nil {
+		return err
+	}
+
+	cancel := client.Orders.CancelOrderByUUID
+	if *byIdentifier {
+		cancel = client.Orders.CancelOrderByIdentifier
+	}
+	order, _, err := cancel(ctx, id[0])
+	if err != nil {
+		return err
+	}
+	return c.print(order)
+}
+
+func runOrderGet(ctx context.Context, c *cli, fs *flag.FlagSet, args []string) error {
+	byIdentifier := fs.Bool("identifier", false, "the argument is the client identifier of the order")
+	id, err := parseArgs(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	client, err := c.connect()
+	if err != nil {
+		return err
+	}
+
+	get := client.Orders.GetOrderByUUID
+	if *byIdentifier {
+		get = client.Orders.GetOrderByIdentifier
+	}
+	order, _, err := get(ctx, id[0])
+	if err != nil {
+		return err
+	}
+	return c.print(order)
+}
+
+func runOrderList(ctx context.Context, c *cli, fs *flag.FlagSet, args []string) error {
+	opts := &upbit.OrderListOptions{}
+	fs.StringVar(&opts.Market, "market", "", "only orders of this market")
+	fs.StringVar(&opts.State, "state", "", "wait, watch, done or cancel; wait by default")
+	fs.IntVar(&opts.Page, "page", 1, "page number")
+	fs.IntVar(&opts.Limit, "limit", 100, "orders per page, at most 100")
+	asc := fs.Bool("asc", false, "oldest first")
+	if _, err := parseArgs(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *asc {
+		opts.OrderBy = "asc"
+	}
+	client, err := c.connect()
+	if err != nil {
+		return err
+	}
+
+	orders, _, err := client.Orders.ListOrders(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return c.print(orders)
+}
+
+func runDeposits(ctx context.Context, c *cli, fs *flag.FlagSet, args []string) error {
+	opts := &upbit.DepositListOptions{}
+	fs.StringVar(&opts.Currency, "currency", "", "only deposits of this currency")
+	fs.StringVar(&opts.State, "state", "", "only deposits in this state, e.g. ACCEPTED")
+	fs.IntVar(&opts.Page, "page", 1, "page number")
+	fs.IntVar(&opts.Limit, "limit", 100, "deposits per page, at most 100")
+	if _, err := parseArgs(fs, args, 0, 0); err != nil {
+		return err
+	}
+	client, err := c.connect()
+	if err != nil {
+		return err
+	}
+
+	deposits, _, err := client.Deposits.ListDeposits(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return c.print(deposits)
+}
+
+func runWithdraws(ctx context.Context, c *cli, fs *flag.FlagSet, args []string) error {
+	opts := &upbit.WithdrawListOptions{}
+	fs.StringVar(&opts.Currency, "currency", "", "only withdrawals of this currency")
+	fs.StringVar(&opts.State, "state", "", "only withdrawals in this state, e.g. DONE")
+	fs.IntVar(&opts.Page, "page", 1, "page number")
+	fs.IntVar(&opts.Limit, "limit", 100, "withdrawals per page, at most 100")
+	if _, err := parseArgs(fs, args, 0, 0); err != nil {
+		return err
+	}
+	client, err := c.connect()
+	if err != nil {
+		return err
+	}
+
+	withdraws, _, err := client.Withdraws.ListWithdraws(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return c.print(withdraws)
+}
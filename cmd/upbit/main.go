@@ -0,0 +1,213 @@
+// Command upbit reads accounts, quotes and candles and places orders on
+// Upbit from the command line:
+//
+//	upbit accounts
+//	upbit ticker KRW-BTC KRW-ETH
+//	upbit candles -interval 15m -count 20 KRW-BTC
+//	upbit order buy -price 50000000 -volume 0.001 KRW-BTC
+//	upbit order list -state done -o json
+//
+// The API keys, server and region are read from the environment as by
+// upbit.ClientOptionsFromEnv. Results print as a table, JSON or CSV with -o;
+// tables and CSV leave out the fields tagged tabulate:"-". With -dry-run the
+// signed requests are printed as curl commands instead of being sent.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+// errUsage is returned for bad arguments, after the usage was printed.
+var errUsage = errors.New("usage")
+
+type cli struct {
+	stdout, stderr io.Writer
+
+	format string
+	dryRun bool
+	debug  bool
+
+	client *upbit.Client
+}
+
+type command struct {
+	name  string
+	args  string
+	short string
+	run   func(ctx context.Context, c *cli, fs *flag.FlagSet, args []string) error
+}
+
+var commands []*command
+
+func init() {
+	commands = []*command{
+		{"accounts", "", "list balances", runAccounts},
+		{"markets", "[-quote KRW] [-caution]", "list markets", runMarkets},
+		{"ticker", "MARKET...", "show the tickers of markets", runTicker},
+		{"candles", "[-interval 1m] [-count N] [-from T] [-to T] MARKET", "list candles, newest first", runCandles},
+		{"orderbook", "MARKET...", "show the orderbooks of markets", runOrderbook},
+		{"order", "buy|sell|cancel|get|list ...", "place, cancel and list orders", runOrder},
+		{"deposits", "[-currency C] [-state S] [-limit N] [-page N]", "list deposits", runDeposits},
+		{"withdraws", "[-currency C] [-state S] [-limit N] [-page N]", "list withdrawals", runWithdraws},
+	}
+}
+
+func main() {
+	c := &cli{stdout: os.Stdout, stderr: os.Stderr}
+	err := c.run(context.Background(), os.Args[1:])
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	default:
+		fmt.Fprintf(os.Stderr, "upbit: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func (c *cli) run(ctx context.Context, args []string) error {
+	// The global flags stop at the command, whose flags come after its name.
+	fs := c.flags("upbit", "COMMAND [ARGS]")
+	if err := fs.Parse(args); err == flag.ErrHelp {
+		return err
+	} else if err != nil {
+		return errUsage
+	}
+	rest := fs.Args()
+	if len(rest) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == rest[0] {
+			err := cmd.run(ctx, c, c.flags("upbit "+cmd.name, cmd.args), rest[1:])
+			if errors.Is(err, upbit.ErrDryRun) {
+				return nil
+			}
+			return err
+		}
+	}
+	fmt.Fprintf(c.stderr, "upbit: unknown command %q\n", rest[0])
+	fs.Usage()
+	return errUsage
+}
+
+// flags returns a FlagSet with the output flags, which every command
+// accepts before or after its own.
+func (c *cli) flags(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	if c.format == "" {
+		c.format = formatTable
+	}
+	fs.StringVar(&c.format, "o", c.format, "output format: "+strings.Join(formats, ", "))
+	fs.BoolVar(&c.dryRun, "dry-run", c.dryRun, "print the signed requests as curl commands instead of sending them")
+	fs.BoolVar(&c.debug, "debug", c.debug, "log requests and responses")
+	fs.Usage = func() {
+		fmt.Fprintf(c.stderr, "usage: %s [flags] %s\n", name, args)
+		subcommands := map[string][]*command{"upbit": commands, "upbit order": orderCommands}[name]
+		if len(subcommands) > 0 {
+			fmt.Fprintln(c.stderr, "\ncommands:")
+			for _, cmd := range subcommands {
+				fmt.Fprintf(c.stderr, "  %-10s %s\n", cmd.name, cmd.short)
+			}
+		}
+		fmt.Fprintln(c.stderr, "\nflags:")
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse parses flags anywhere among the positional arguments, which it
+// returns. Errors other than flag.ErrHelp become errUsage, the FlagSet
+// having printed them.
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err == flag.ErrHelp {
+			return nil, err
+		} else if err != nil {
+			return nil, errUsage
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// connect creates the client once the flags are known.
+func (c *cli) connect() (*upbit.Client, error) {
+	if c.client != nil {
+		return c.client, nil
+	}
+	if !contains(formats, c.format) {
+		return nil, fmt.Errorf("unknown output format %q, want one of %s", c.format, strings.Join(formats, ", "))
+	}
+
+	opts := upbit.ClientOptionsFromEnv()
+	opts.Debug = c.debug
+	opts.DryRun = c.dryRun
+	opts.PriceUnitPolicy = upbit.PriceUnitValidate
+	if c.dryRun {
+		log.SetOutput(c.stdout)
+		log.SetFlags(0)
+	}
+
+	client, err := upbit.NewClient(nil, opts)
+	if err != nil {
+		return nil, err
+	}
+	c.client = client
+	return client, nil
+}
+
+func (c *cli) print(v interface{}) error {
+	return write(c.stdout, c.format, v)
+}
+
+// parseArgs parses the flags of a command and checks that it got min to max
+// positional arguments, any number above min if max is negative.
+func parseArgs(fs *flag.FlagSet, args []string, min, max int) ([]string, error) {
+	positional, err := parse(fs, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(positional) < min || max >= 0 && len(positional) > max {
+		return nil, usage(fs, "got %d arguments", len(positional))
+	}
+	return positional, nil
+}
+
+// usage prints the usage of fs and returns errUsage.
+func usage(fs *flag.FlagSet, format string, args ...interface{}) error {
+	if format != "" {
+		fmt.Fprintf(fs.Output(), "%s: %s\n", fs.Name(), fmt.Sprintf(format, args...))
+	}
+	fs.Usage()
+	return errUsage
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+	"github.com/investing-kr/go-upbit/upbittest"
+)
+
+func TestWrite(t *testing.T) {
+	orders := []*upbit.Order{{
+		UUID:      "1",
+		Side:      upbit.SideBid,
+		OrdType:   upbit.OrdTypeLimit,
+		Price:     "100",
+		AvgPrice:  "100",
+		State:     "wait",
+		Market:    upbit.KRW_BTC,
+		CreatedAt: time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+		Volume:    "1",
+	}}
+
+	var buf bytes.Buffer
+	if err := write(&buf, formatTable, orders); err != nil {
+		t.Fatal(err)
+	}
+	want := "UUID  SIDE  ORD_TYPE  PRICE  STATE  MARKET   CREATED_AT            VOLUME\n" +
+		"1     bid   limit     100    wait   KRW-BTC  2021-01-02T03:04:05Z  1\n"
+	if buf.String() != want {
+		t.Errorf("table =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := write(&buf, formatCSV, orders[0]); err != nil {
+		t.Fatal(err)
+	}
+	want = "uuid,side,ord_type,price,state,market,created_at,volume,remaining_volume\n" +
+		"1,bid,limit,100,wait,KRW-BTC,2021-01-02T03:04:05Z,1,\n"
+	if buf.String() != want {
+		t.Errorf("csv =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := write(&buf, formatJSON, orders); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"avg_price": "100"`) {
+		t.Errorf("json = %s, want every field", buf.String())
+	}
+}
+
+// newCLI returns a cli connected to srv through the environment.
+func newCLI(t *testing.T, srv *upbittest.Server, dryRun bool) (*cli, *bytes.Buffer) {
+	t.Helper()
+	for k, v := range map[string]string{
+		"UPBIT_OPEN_API_SERVER_URL": srv.URL,
+		"UPBIT_OPEN_API_ACCESS_KEY": srv.AccessKey,
+		"UPBIT_OPEN_API_SECRET_KEY": srv.SecretKey,
+	} {
+		old, ok := os.LookupEnv(k)
+		os.Setenv(k, v)
+		if ok {
+			defer os.Setenv(k, old)
+		} else {
+			defer os.Unsetenv(k)
+		}
+	}
+	var stdout, stderr bytes.Buffer
+	c := &cli{stdout: &stdout, stderr: &stderr, format: formatTable, dryRun: dryRun}
+	if _, err := c.connect(); err != nil {
+		t.Fatal(err)
+	}
+	return c, &stdout
+}
+
+func TestCommands(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+	if err := srv.AddMarket(upbit.MarketCode{Market: upbit.KRW_BTC, KoreanName: "비트코인", EnglishName: "Bitcoin"}); err != nil {
+		t.Fatal(err)
+	}
+	srv.SetBalance("KRW", "1000000")
+	ctx := context.Background()
+
+	c, stdout := newCLI(t, srv, false)
+	if err := c.run(ctx, []string{"order", "buy", "-price", "100000", "-volume", "1", upbit.KRW_BTC, "-o", "json"}); err != nil {
+		t.Fatal(err)
+	}
+	orders := srv.Orders()
+	if len(orders) != 1 || orders[0].OrdType != upbit.OrdTypeLimit || orders[0].Side != upbit.SideBid {
+		t.Fatalf("orders = %+v", orders)
+	}
+	if !strings.Contains(stdout.String(), `"uuid": "`+orders[0].UUID+`"`) {
+		t.Errorf("order output = %s", stdout.String())
+	}
+
+	stdout.Reset()
+	if err := c.run(ctx, []string{"-o", "csv", "accounts"}); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(stdout.String()), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[1], "KRW,899950,100050") {
+		t.Errorf("accounts = %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if err := c.run(ctx, []string{"order", "cancel", orders[0].UUID}); err != nil {
+		t.Fatal(err)
+	}
+	if orders := srv.Orders(); orders[0].State != "cancel" {
+		t.Errorf("state = %s, want cancel", orders[0].State)
+	}
+
+	if err := c.run(ctx, []string{"order", "buy", upbit.KRW_BTC}); err != errUsage {
+		t.Errorf("buy without price err = %v, want %v", err, errUsage)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+	if err := srv.AddMarket(upbit.MarketCode{Market: upbit.KRW_BTC}); err != nil {
+		t.Fatal(err)
+	}
+	srv.SetBalance("KRW", "1000000")
+
+	defer log.SetOutput(os.Stderr)
+	defer log.SetFlags(log.LstdFlags)
+	c, stdout := newCLI(t, srv, true)
+	if err := c.run(context.Background(), []string{"order", "sell", "-volume", "0.1", upbit.KRW_BTC}); err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.Orders()) != 0 {
+		t.Errorf("orders = %+v, want none sent", srv.Orders())
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "curl") || !strings.Contains(out, "Authorization: Bearer ") || !strings.Contains(out, srv.URL+"/v1/orders") {
+		t.Errorf("output = %s, want a signed curl command", out)
+	}
+}
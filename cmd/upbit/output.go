@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+var formats = []string{formatTable, formatJSON, formatCSV}
+
+// column is a field of a struct printed as a table or CSV column. Fields
+// tagged tabulate:"-" are left out and tabulate:"name" renames the column;
+// otherwise the column is named after the JSON field.
+type column struct {
+	name  string
+	index []int
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func columns(t reflect.Type) []column {
+	var cols []column
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tag, ok := f.Tag.Lookup("tabulate"); ok {
+			if tag == "-" {
+				continue
+			}
+			name = tag
+		}
+		if name == "" || name == "-" {
+			name = f.Name
+		}
+
+		switch f.Type.Kind() {
+		case reflect.Slice, reflect.Map, reflect.Ptr, reflect.Interface:
+			continue
+		case reflect.Struct:
+			if f.Type != timeType {
+				continue
+			}
+		}
+		cols = append(cols, column{name: name, index: f.Index})
+	}
+	return cols
+}
+
+// rows returns the structs v holds: a struct, a pointer to one or a slice of
+// either.
+func rows(v interface{}) (reflect.Type, []reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	var values []reflect.Value
+	if rv.Kind() == reflect.Slice {
+		for i := 0; i < rv.Len(); i++ {
+			values = append(values, reflect.Indirect(rv.Index(i)))
+		}
+		t := rv.Type().Elem()
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		return t, values, nil
+	}
+
+	rv = reflect.Indirect(rv)
+	if rv.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("cannot tabulate %T", v)
+	}
+	return rv.Type(), []reflect.Value{rv}, nil
+}
+
+func cell(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// write prints v to w in format. JSON carries every field of v; tables and
+// CSV have a column per field as by columns, and tables leave out the
+// columns empty in every row.
+func write(w io.Writer, format string, v interface{}) error {
+	if format == formatJSON {
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", b)
+		return err
+	}
+
+	t, values, err := rows(v)
+	if err != nil {
+		return err
+	}
+	cols := columns(t)
+	table := make([][]string, len(values))
+	for i, row := range values {
+		table[i] = make([]string, len(cols))
+		for j, col := range cols {
+			table[i][j] = cell(row.FieldByIndex(col.index))
+		}
+	}
+
+	switch format {
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		header := make([]string, len(cols))
+		for j, col := range cols {
+			header[j] = col.name
+		}
+		cw.Write(header)
+		cw.WriteAll(table)
+		return cw.Error()
+
+	case formatTable:
+		var keep []int
+		for j := range cols {
+			for i := range table {
+				if table[i][j] != "" {
+					keep = append(keep, j)
+					break
+				}
+			}
+		}
+		if len(table) == 0 {
+			for j := range cols {
+				keep = append(keep, j)
+			}
+		}
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		line := make([]string, len(keep))
+		for k, j := range keep {
+			line[k] = strings.ToUpper(cols[j].name)
+		}
+		fmt.Fprintln(tw, strings.Join(line, "\t"))
+		for _, row := range table {
+			for k, j := range keep {
+				line[k] = row[j]
+			}
+			fmt.Fprintln(tw, strings.Join(line, "\t"))
+		}
+		return tw.Flush()
+	}
+	return fmt.Errorf("unknown output format %q, want one of %s", format, strings.Join(formats, ", "))
+}
@@ -0,0 +1,198 @@
+package upbit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+// Decimal is an exact decimal number such as a price, volume or balance.
+//
+// Decimal keeps the text Upbit sent, so it converts to and from the string
+// fields of earlier versions without loss:
+//
+//	req := &upbit.OrderRequest{Volume: "0.01", Price: upbit.Decimal(price)}
+//	balance := string(account.Balance)
+//
+// It unmarshals from both JSON strings and numbers, marshals to a JSON string
+// and encodes to query strings as is. An empty Decimal is zero in arithmetic
+// and null in JSON. Arithmetic is arbitrary precision except Div, which
+// rounds to DivisionPrecision digits. Use Equal or Cmp, not ==, to compare
+// values since "1.0" and "1" are equal numbers but different strings.
+// Arithmetic panics on a Decimal that is not a number; NewDecimal and
+// UnmarshalJSON reject such values, and ValidateOrder and NewBook return an
+// error for them.
+type Decimal string
+
+// DivisionPrecision is the number of fractional digits kept by Div. Use
+// DivRound for another precision.
+const DivisionPrecision = 16
+
+func NewDecimal(s string) (Decimal, error) {
+	if s == "" {
+		return "", nil
+	}
+	if _, err := decimal.NewFromString(s); err != nil {
+		return "", fmt.Errorf("upbit: invalid decimal %q", s)
+	}
+	return Decimal(s), nil
+}
+
+// checkDecimals returns the error of NewDecimal for the first of ds that is
+// not a number.
+func checkDecimals(ds ...Decimal) error {
+	for _, d := range ds {
+		if _, err := NewDecimal(string(d)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func DecimalFromFloat(f float64) Decimal {
+	return Decimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+func DecimalFromInt(i int64) Decimal {
+	return Decimal(strconv.FormatInt(i, 10))
+}
+
+func newDecimal(d decimal.Decimal) Decimal {
+	return Decimal(d.String())
+}
+
+func (d Decimal) dec() decimal.Decimal {
+	if d == "" {
+		return decimal.Zero
+	}
+	v, err := decimal.NewFromString(string(d))
+	if err != nil {
+		panic(fmt.Sprintf("upbit: invalid decimal %q", string(d)))
+	}
+	return v
+}
+
+func (d Decimal) String() string {
+	return string(d)
+}
+
+func (d Decimal) Float64() float64 {
+	f, _ := d.dec().Float64()
+	return f
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	return newDecimal(d.dec().Add(o.dec()))
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	return newDecimal(d.dec().Sub(o.dec()))
+}
+
+func (d Decimal) Mul(o Decimal) Decimal {
+	return newDecimal(d.dec().Mul(o.dec()))
+}
+
+// Div panics if o is zero.
+func (d Decimal) Div(o Decimal) Decimal {
+	return d.DivRound(o, DivisionPrecision)
+}
+
+// DivRound divides by o, rounding half away from zero to places fractional
+// digits. It panics if o is zero.
+func (d Decimal) DivRound(o Decimal, places int32) Decimal {
+	return newDecimal(d.dec().DivRound(o.dec(), places))
+}
+
+func (d Decimal) Neg() Decimal {
+	return newDecimal(d.dec().Neg())
+}
+
+func (d Decimal) Abs() Decimal {
+	return newDecimal(d.dec().Abs())
+}
+
+// Round rounds half away from zero to places fractional digits.
+func (d Decimal) Round(places int32) Decimal {
+	return newDecimal(d.dec().Round(places))
+}
+
+// Truncate drops fractional digits beyond places.
+func (d Decimal) Truncate(places int32) Decimal {
+	return newDecimal(d.dec().Truncate(places))
+}
+
+func (d Decimal) Cmp(o Decimal) int {
+	return d.dec().Cmp(o.dec())
+}
+
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+func (d Decimal) LessThan(o Decimal) bool {
+	return d.Cmp(o) < 0
+}
+
+func (d Decimal) GreaterThan(o Decimal) bool {
+	return d.Cmp(o) > 0
+}
+
+func (d Decimal) Sign() int {
+	return d.dec().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+func MinDecimal(first Decimal, rest ...Decimal) Decimal {
+	min := first
+	for _, d := range rest {
+		if d.LessThan(min) {
+			min = d
+		}
+	}
+	return min
+}
+
+func MaxDecimal(first Decimal, rest ...Decimal) Decimal {
+	max := first
+	for _, d := range rest {
+		if d.GreaterThan(max) {
+			max = d
+		}
+	}
+	return max
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if d == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(string(d))
+}
+
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*d = ""
+		return nil
+	}
+
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	}
+
+	v, err := NewDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
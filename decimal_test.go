@@ -0,0 +1,82 @@
+package upbit_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-querystring/query"
+	"github.com/investing-kr/go-upbit"
+)
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		S upbit.Decimal `json:"s"`
+		N upbit.Decimal `json:"n"`
+		Z upbit.Decimal `json:"z"`
+	}
+	if err := json.Unmarshal([]byte(`{"s":"0.00012345","n":43512000.5,"z":null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.S != "0.00012345" || v.N != "43512000.5" || v.Z != "" {
+		t.Errorf("unmarshal = %+v", v)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"s":"0.00012345","n":"43512000.5","z":null}` {
+		t.Errorf("marshal = %s", b)
+	}
+
+	if err := json.Unmarshal([]byte(`{"s":"abc"}`), &v); err == nil {
+		t.Error("invalid decimal accepted")
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, b := upbit.Decimal("0.1"), upbit.Decimal("0.2")
+	if sum := a.Add(b); sum != "0.3" {
+		t.Errorf("0.1 + 0.2 = %s", sum)
+	}
+	if d := upbit.Decimal("1").Sub("0.00000001"); d != "0.99999999" {
+		t.Errorf("1 - 0.00000001 = %s", d)
+	}
+	if m := upbit.Decimal("43512000").Mul("0.0005"); !m.Equal("21756") {
+		t.Errorf("43512000 * 0.0005 = %s", m)
+	}
+	if q := upbit.Decimal("10").Div("4"); q != "2.5" {
+		t.Errorf("10 / 4 = %s", q)
+	}
+	if q := upbit.Decimal("2").DivRound("3", 4); q != "0.6667" {
+		t.Errorf("2 / 3 to 4 places = %s", q)
+	}
+	if !upbit.Decimal("1.0").Equal("1") || upbit.Decimal("1.0") == "1" {
+		t.Error("Equal must compare numerically")
+	}
+	if !upbit.Decimal("").IsZero() || upbit.Decimal("-2").Sign() != -1 {
+		t.Error("IsZero/Sign mismatch")
+	}
+	if max := upbit.MaxDecimal("1", "3", "2"); max != "3" {
+		t.Errorf("MaxDecimal = %s", max)
+	}
+	if f := upbit.Decimal("1.5").Float64(); f != 1.5 {
+		t.Errorf("Float64 = %v", f)
+	}
+}
+
+func TestDecimalQuery(t *testing.T) {
+	qv, err := query.Values(&upbit.OrderRequest{
+		Market:  upbit.KRW_BTC,
+		Side:    upbit.SideBid,
+		Volume:  "0.01",
+		Price:   upbit.DecimalFromFloat(43512000),
+		OrdType: upbit.OrdTypeLimit,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qs := qv.Encode(); qs != "market=KRW-BTC&ord_type=limit&price=43512000&side=bid&volume=0.01" {
+		t.Errorf("query = %s", qs)
+	}
+}
@@ -2,9 +2,20 @@ package upbit
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/go-querystring/query"
 )
 
+// ErrCoinAddressCreating is returned by GenerateCoinAddress when Upbit accepted
+// the request but is still issuing the address asynchronously.
+var ErrCoinAddressCreating = fmt.Errorf("upbit: coin address is being created")
+
 func (s *DepositService) ListCoinAddresses(ctx context.Context) ([]*CoinAddress, *http.Response, error) {
 	u := "v1/deposits/coin_addresses"
 	req, err := s.client.NewRequest(http.MethodGet, u, nil)
@@ -23,5 +34,224 @@ func (s *DepositService) ListCoinAddresses(ctx context.Context) ([]*CoinAddress,
 		return nil, resp, err
 	}
 
-	return addrs, nil, nil
+	return addrs, resp, nil
+}
+
+func (s *DepositService) GetCoinAddress(ctx context.Context, currency, netType string) (*CoinAddress, *http.Response, error) {
+	params := url.Values{}
+	params.Add("currency", currency)
+	if netType != "" {
+		params.Add("net_type", netType)
+	}
+	qs := params.Encode()
+
+	u := fmt.Sprintf("v1/deposits/coin_address?%s", qs)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.client.generateToken(req, qs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addr := &CoinAddress{}
+	resp, err := s.client.Do(ctx, req, addr)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return addr, resp, nil
+}
+
+// GenerateCoinAddress requests a deposit address for the currency. Upbit issues
+// addresses asynchronously, so the first call usually returns
+// ErrCoinAddressCreating together with the server message. Use
+// WaitCoinAddress to poll until the address is ready.
+func (s *DepositService) GenerateCoinAddress(ctx context.Context, currency, netType string) (*CoinAddress, *CoinAddressCreating, *http.Response, error) {
+	params := url.Values{}
+	params.Add("currency", currency)
+	if netType != "" {
+		params.Add("net_type", netType)
+	}
+	qs := params.Encode()
+
+	u := fmt.Sprintf("v1/deposits/generate_coin_address?%s", qs)
+	req, err := s.client.NewRequest(http.MethodPost, u, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	err = s.client.generateToken(req, qs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var raw json.RawMessage
+	resp, err := s.client.Do(ctx, req, &raw)
+	if err != nil {
+		return nil, nil, resp, err
+	}
+
+	creating := &CoinAddressCreating{}
+	if err := json.Unmarshal(raw, creating); err == nil && creating.Message != "" {
+		return nil, creating, resp, ErrCoinAddressCreating
+	}
+
+	addr := &CoinAddress{}
+	if err := json.Unmarshal(raw, addr); err != nil {
+		return nil, nil, resp, err
+	}
+
+	return addr, nil, resp, nil
+}
+
+// DefaultCoinAddressInterval is the polling interval of WaitCoinAddress when
+// none is given.
+const DefaultCoinAddressInterval = time.Second
+
+// WaitCoinAddress generates a deposit address if necessary and polls
+// GetCoinAddress every interval, DefaultCoinAddressInterval if interval is
+// not positive, until it is issued or ctx is done.
+func (s *DepositService) WaitCoinAddress(ctx context.Context, currency, netType string, interval time.Duration) (*CoinAddress, error) {
+	if interval <= 0 {
+		interval = DefaultCoinAddressInterval
+	}
+
+	addr, _, _, err := s.GenerateCoinAddress(ctx, currency, netType)
+	if err == nil && addr.DepositAddress != "" {
+		return addr, nil
+	}
+	if err != nil && !errors.Is(err, ErrCoinAddressCreating) {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		addr, _, err := s.GetCoinAddress(ctx, currency, netType)
+		if err != nil {
+			if errors.Is(err, ErrCoinAddressNotFound) {
+				continue
+			}
+			return nil, err
+		}
+
+		if addr.DepositAddress != "" {
+			return addr, nil
+		}
+	}
+}
+
+func (s *DepositService) ListDeposits(ctx context.Context, listOpt *DepositListOptions) ([]*Deposit, *http.Response, error) {
+	qv, err := query.Values(listOpt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	queryString := qv.Encode()
+	u := fmt.Sprintf("v1/deposits?%s", queryString)
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.client.generateToken(req, queryString)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deposits := []*Deposit{}
+	resp, err := s.client.Do(ctx, req, &deposits)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return deposits, resp, nil
+}
+
+func (s *DepositService) getDeposit(ctx context.Context, queryString string) (*Deposit, *http.Response, error) {
+	u := fmt.Sprintf("v1/deposit?%s", queryString)
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.client.generateToken(req, queryString)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deposit := &Deposit{}
+	resp, err := s.client.Do(ctx, req, deposit)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return deposit, resp, nil
+}
+
+func (s *DepositService) GetDeposit(ctx context.Context, uuid string) (*Deposit, *http.Response, error) {
+	return s.GetDepositByUUID(ctx, uuid)
+}
+
+func (s *DepositService) GetDepositByUUID(ctx context.Context, uuid string) (*Deposit, *http.Response, error) {
+	params := url.Values{}
+	params.Add("uuid", uuid)
+	qs := params.Encode()
+
+	return s.getDeposit(ctx, qs)
+}
+
+func (s *DepositService) GetDepositByTxID(ctx context.Context, currency, txid string) (*Deposit, *http.Response, error) {
+	params := url.Values{}
+	params.Add("currency", currency)
+	params.Add("txid", txid)
+	qs := params.Encode()
+
+	return s.getDeposit(ctx, qs)
+}
+
+func (s *DepositService) DepositKRW(ctx context.Context, depositReq *DepositKRWRequest) (*Deposit, *http.Response, error) {
+	if err := s.client.supports(featureKRW); err != nil {
+		return nil, nil, err
+	}
+	if depositReq == nil || depositReq.Amount == "" || depositReq.TwoFactorType == "" {
+		return nil, nil, ErrInvalidArguments
+	}
+
+	qv, err := query.Values(depositReq)
+	if err != nil {
+		return nil, nil, err
+	}
+	qs := qv.Encode()
+
+	u := fmt.Sprintf("v1/deposits/krw?%s", qs)
+	req, err := s.client.NewRequest(http.MethodPost, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.client.generateToken(req, qs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deposit := &Deposit{}
+	resp, err := s.client.Do(ctx, req, deposit)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return deposit, resp, nil
 }
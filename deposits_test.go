@@ -0,0 +1,122 @@
+package upbit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+func newPrivateClient(t *testing.T, handler http.HandlerFunc) (*upbit.Client, func()) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	client, err := upbit.NewClient(nil, &upbit.ClientOptions{
+		AccessKey: "access",
+		SecretKey: "secret",
+		ServerURL: srv.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, srv.Close
+}
+
+func TestGenerateCoinAddress(t *testing.T) {
+	var issued int32
+	client, closeServer := newPrivateClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/deposits/generate_coin_address" || r.URL.Query().Get("currency") != "BTC" {
+			http.NotFound(w, r)
+			return
+		}
+		if atomic.AddInt32(&issued, 1) == 1 {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"success":true,"message":"BTC 입금주소를 생성중입니다."}`))
+			return
+		}
+		w.Write([]byte(`{"currency":"BTC","deposit_address":"3EusRwybuZUhVDeHL7gh3HSLmbhLcy7NqD"}`))
+	})
+	defer closeServer()
+
+	ctx := context.Background()
+	addr, creating, _, err := client.Deposits.GenerateCoinAddress(ctx, "BTC", "")
+	if !errors.Is(err, upbit.ErrCoinAddressCreating) || addr != nil || creating == nil || !creating.Success {
+		t.Fatalf("first call = %v, %+v, %v, want ErrCoinAddressCreating", addr, creating, err)
+	}
+
+	addr, creating, _, err = client.Deposits.GenerateCoinAddress(ctx, "BTC", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creating != nil || addr.DepositAddress != "3EusRwybuZUhVDeHL7gh3HSLmbhLcy7NqD" {
+		t.Errorf("second call = %+v, %+v", addr, creating)
+	}
+}
+
+func TestWaitCoinAddress(t *testing.T) {
+	var polls int32
+	client, closeServer := newPrivateClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/deposits/generate_coin_address":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"success":true,"message":"creating"}`))
+		case "/v1/deposits/coin_address":
+			if atomic.AddInt32(&polls, 1) < 3 {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":{"name":"coin_address_not_found","message":"not found"}}`))
+				return
+			}
+			w.Write([]byte(`{"currency":"BTC","deposit_address":"addr"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer closeServer()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	addr, err := client.Deposits.WaitCoinAddress(ctx, "BTC", "", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&polls); addr.DepositAddress != "addr" || n != 3 {
+		t.Errorf("address = %+v after %d polls, want addr after 3", addr, n)
+	}
+
+	// A zero interval polls every DefaultCoinAddressInterval.
+	addr, err = client.Deposits.WaitCoinAddress(ctx, "BTC", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr.DepositAddress != "addr" {
+		t.Errorf("address = %+v", addr)
+	}
+}
+
+func TestListDeposits(t *testing.T) {
+	client, closeServer := newPrivateClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/v1/deposits" || q.Get("currency") != "KRW" || q.Get("limit") != "10" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") == "" {
+			t.Error("request is not signed")
+		}
+		w.Write([]byte(`[{"type":"deposit","uuid":"u","currency":"KRW","state":"ACCEPTED","amount":"10000.0","fee":"0.0"}]`))
+	})
+	defer closeServer()
+
+	deposits, _, err := client.Deposits.ListDeposits(context.Background(), &upbit.DepositListOptions{Currency: "KRW", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deposits) != 1 || deposits[0].UUID != "u" || !deposits[0].Amount.Equal("10000") {
+		t.Errorf("deposits = %+v", deposits)
+	}
+}
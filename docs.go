@@ -1 +1,11 @@
 package upbit
+
+// The market code constants are generated from the market/all responses
+// recorded in cmd/marketcodes/testdata. Record them again to follow
+// listings, e.g. with
+//
+//	curl 'https://api.upbit.com/v1/market/all?isDetail=true' > cmd/marketcodes/testdata/market_all_kr.json
+//
+//go:generate go run ./cmd/marketcodes -region kr -in cmd/marketcodes/testdata/market_all_kr.json
+//go:generate go run ./cmd/marketcodes -region sg -in cmd/marketcodes/testdata/market_all_sg.json
+//go:generate go run ./cmd/marketcodes -region id -in cmd/marketcodes/testdata/market_all_id.json
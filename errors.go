@@ -0,0 +1,112 @@
+package upbit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// Errors documented by Upbit. An *ErrResponse matches the sentinel of its
+// error name with errors.Is:
+//
+//	if errors.Is(err, upbit.ErrInsufficientFundsBid) { ... }
+var (
+	ErrCreateAskError               = newNamedError("create_ask_error")
+	ErrCreateBidError               = newNamedError("create_bid_error")
+	ErrInsufficientFundsAsk         = newNamedError("insufficient_funds_ask")
+	ErrInsufficientFundsBid         = newNamedError("insufficient_funds_bid")
+	ErrUnderMinTotalAsk             = newNamedError("under_min_total_ask")
+	ErrUnderMinTotalBid             = newNamedError("under_min_total_bid")
+	ErrInvalidVolumeAsk             = newNamedError("invalid_volume_ask")
+	ErrInvalidVolumeBid             = newNamedError("invalid_volume_bid")
+	ErrInvalidPriceAsk              = newNamedError("invalid_price_ask")
+	ErrInvalidPriceBid              = newNamedError("invalid_price_bid")
+	ErrWithdrawAddressNotRegistered = newNamedError("withdraw_address_not_registerd") // sic
+	ErrValidationError              = newNamedError("validation_error")
+	ErrInvalidParameter             = newNamedError("invalid_parameter")
+	ErrInvalidQueryPayload          = newNamedError("invalid_query_payload")
+	ErrJWTVerification              = newNamedError("jwt_verification")
+	ErrExpiredAccessKey             = newNamedError("expired_access_key")
+	ErrInvalidAccessKey             = newNamedError("invalid_access_key")
+	ErrNonceUsed                    = newNamedError("nonce_used")
+	ErrNoAuthorizationIP            = newNamedError("no_authorization_i_p")
+	ErrOutOfScope                   = newNamedError("out_of_scope")
+	ErrOrderNotFound                = newNamedError("order_not_found")
+	ErrMarketDoesNotExist           = newNamedError("market_does_not_exist")
+	ErrCoinAddressNotFound          = newNamedError("coin_address_not_found")
+	ErrServerError                  = newNamedError("server_error")
+
+	// ErrTooManyRequests also matches every 429 response, which Upbit sends
+	// without a structured body.
+	ErrTooManyRequests = newNamedError("too_many_requests")
+)
+
+// namedError is the sentinel of an Upbit error name.
+type namedError struct {
+	name string
+}
+
+func (e *namedError) Error() string {
+	return "upbit: " + e.name
+}
+
+var namedErrors = map[string]*namedError{}
+
+func newNamedError(name string) error {
+	e := &namedError{name: name}
+	namedErrors[name] = e
+	return e
+}
+
+// Is reports whether target is the sentinel of e's error name.
+func (e *ErrResponse) Is(target error) bool {
+	if target == ErrTooManyRequests && e.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	named, ok := namedErrors[e.Detail.Name]
+	return ok && target == error(named)
+}
+
+func anyIs(err error, targets ...error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRetryable reports whether the request may succeed if sent again: a
+// network error, 429 or a 5xx response. It does not tell whether a non-GET
+// request is safe to repeat.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var errResp *ErrResponse
+	if errors.As(err, &errResp) {
+		return errResp.StatusCode == http.StatusTooManyRequests || errResp.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// IsAuthError reports whether err was caused by the API key, its token or
+// its permissions.
+func IsAuthError(err error) bool {
+	if anyIs(err, ErrJWTVerification, ErrExpiredAccessKey, ErrInvalidAccessKey,
+		ErrNonceUsed, ErrNoAuthorizationIP, ErrOutOfScope) {
+		return true
+	}
+
+	var errResp *ErrResponse
+	return errors.As(err, &errResp) && errResp.StatusCode == http.StatusUnauthorized
+}
+
+func IsInsufficientFunds(err error) bool {
+	return anyIs(err, ErrInsufficientFundsAsk, ErrInsufficientFundsBid)
+}
@@ -0,0 +1,54 @@
+package upbit_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+func newErrorResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func TestCheckResponse(t *testing.T) {
+	err := upbit.CheckResponse(newErrorResponse(http.StatusBadRequest,
+		`{"error":{"name":"insufficient_funds_bid","message":"주문가능한 금액(KRW)이 부족합니다."}}`))
+
+	if !errors.Is(err, upbit.ErrInsufficientFundsBid) || errors.Is(err, upbit.ErrInsufficientFundsAsk) {
+		t.Errorf("errors.Is mismatch for %v", err)
+	}
+	if !upbit.IsInsufficientFunds(err) || upbit.IsAuthError(err) || upbit.IsRetryable(err) {
+		t.Errorf("helpers mismatch for %v", err)
+	}
+
+	var errResp *upbit.ErrResponse
+	if !errors.As(err, &errResp) || errResp.StatusCode != http.StatusBadRequest || len(errResp.Body) == 0 {
+		t.Errorf("errors.As = %+v", errResp)
+	}
+
+	err = upbit.CheckResponse(newErrorResponse(http.StatusUnauthorized,
+		`{"error":{"name":"nonce_used","message":"이미 요청한 nonce값이 다시 사용되었습니다."}}`))
+	if !errors.Is(err, upbit.ErrNonceUsed) || !upbit.IsAuthError(err) {
+		t.Errorf("nonce_used not an auth error: %v", err)
+	}
+
+	err = upbit.CheckResponse(newErrorResponse(http.StatusTooManyRequests, "Too many API requests."))
+	if !errors.Is(err, upbit.ErrTooManyRequests) || !upbit.IsRetryable(err) {
+		t.Errorf("429 mismatch: %v", err)
+	}
+	if err.Error() != "upbit: 429 Too many API requests." {
+		t.Errorf("Error() = %q", err.Error())
+	}
+
+	err = upbit.CheckResponse(newErrorResponse(http.StatusBadGateway, "<html></html>"))
+	if !upbit.IsRetryable(err) {
+		t.Errorf("502 is not retryable: %v", err)
+	}
+}
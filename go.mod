@@ -6,5 +6,7 @@ require (
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/google/go-querystring v1.0.0
 	github.com/google/uuid v1.1.2
+	github.com/gorilla/websocket v1.4.2
+	github.com/shopspring/decimal v1.2.0
 	moul.io/http2curl v1.0.0
 )
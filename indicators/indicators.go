@@ -0,0 +1,161 @@
+// Package indicators computes technical indicators over Upbit candles.
+//
+// Batch functions take a candle slice as returned by CandleService, newest
+// first, or oldest first as returned by CandleService.Range, and return a
+// series of the same length aligned with it: the value at index i belongs to
+// candles[i]. Values are NaN until the indicator has seen enough candles,
+// and throughout for a period below 1.
+//
+// Streaming indicators, created with NewSMAStream, NewRSIStream and so on,
+// are updated one candle at a time in chronological order. Updating with a
+// candle of the same start time as the previous one revises the last value
+// instead of adding a new one, so the candle in progress can be fed on every
+// tick from the WebSocket or REST polling:
+//
+//	rsi := indicators.NewRSIStream(14)
+//	for candle := range candles {
+//		if v, ok := rsi.Update(candle); ok { ... }
+//	}
+package indicators
+
+import (
+	"math"
+	"sort"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+// Series is a value per candle, NaN where the indicator is not defined.
+type Series []float64
+
+// Chronological returns candles sorted oldest first.
+func Chronological(candles []*upbit.Candle) []*upbit.Candle {
+	sorted := append([]*upbit.Candle(nil), candles...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CandleDateTimeUtc < sorted[j].CandleDateTimeUtc
+	})
+	return sorted
+}
+
+func newestFirst(candles []*upbit.Candle) bool {
+	return len(candles) > 1 && candles[0].CandleDateTimeUtc > candles[len(candles)-1].CandleDateTimeUtc
+}
+
+// state is the committed state of a streaming indicator. next must not
+// modify the receiver, so that the candle in progress can be revised.
+type state interface {
+	next(c *upbit.Candle) (state, []float64)
+}
+
+// stream commits a candle once a candle of a later start time arrives.
+type stream struct {
+	committed state
+	pending   *upbit.Candle
+}
+
+func (s *stream) update(c *upbit.Candle) []float64 {
+	if s.pending != nil && s.pending.CandleDateTimeUtc != c.CandleDateTimeUtc {
+		s.committed, _ = s.committed.next(s.pending)
+	}
+	s.pending = c
+	_, values := s.committed.next(c)
+	return values
+}
+
+// run feeds candles to initial in chronological order and returns n series
+// aligned with candles.
+func run(candles []*upbit.Candle, initial state, n int) []Series {
+	series := make([]Series, n)
+	for k := range series {
+		series[k] = make(Series, len(candles))
+	}
+
+	reversed := newestFirst(candles)
+	s := initial
+	for j := range candles {
+		i := j
+		if reversed {
+			i = len(candles) - 1 - j
+		}
+
+		var values []float64
+		s, values = s.next(candles[i])
+		for k := range series {
+			if values == nil {
+				series[k][i] = math.NaN()
+			} else {
+				series[k][i] = values[k]
+			}
+		}
+	}
+	return series
+}
+
+func closePrice(c *upbit.Candle) float64 {
+	return c.TradePrice.Float64()
+}
+
+// window is a fixed size window over the last values. A window of size
+// below 1 is never full.
+type window struct {
+	size   int
+	values []float64
+}
+
+func (w window) push(v float64) window {
+	if w.size < 1 {
+		return w
+	}
+	values := append(append(make([]float64, 0, w.size), w.values...), v)
+	if len(values) > w.size {
+		values = values[1:]
+	}
+	return window{size: w.size, values: values}
+}
+
+func (w window) full() bool {
+	return w.size > 0 && len(w.values) == w.size
+}
+
+func (w window) mean() float64 {
+	var sum float64
+	for _, v := range w.values {
+		sum += v
+	}
+	return sum / float64(len(w.values))
+}
+
+// ema is an exponential moving average seeded with the simple average of
+// its first period values. smoothing is 2/(period+1), or 1/period for
+// Wilder's smoothing. An ema of period below 1 has no value.
+type ema struct {
+	period    int
+	smoothing float64
+	count     int
+	value     float64
+}
+
+func newEMA(period int) ema {
+	return ema{period: period, smoothing: 2 / float64(period+1)}
+}
+
+func newWilder(period int) ema {
+	return ema{period: period, smoothing: 1 / float64(period)}
+}
+
+func (e ema) push(v float64) (ema, bool) {
+	if e.period < 1 {
+		return e, false
+	}
+	e.count++
+	switch {
+	case e.count < e.period:
+		e.value += v
+		return e, false
+	case e.count == e.period:
+		e.value = (e.value + v) / float64(e.period)
+	default:
+		e.value += e.smoothing * (v - e.value)
+	}
+	return e, true
+}
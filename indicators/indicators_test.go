@@ -0,0 +1,145 @@
+package indicators_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+	"github.com/investing-kr/go-upbit/indicators"
+)
+
+// candles returns candles closing at closes, one minute apart, oldest first.
+func candles(closes ...float64) []*upbit.Candle {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var cs []*upbit.Candle
+	for i, c := range closes {
+		cs = append(cs, &upbit.Candle{
+			CandleDateTimeUtc:    start.Add(time.Duration(i) * time.Minute).Format("2006-01-02T15:04:05"),
+			HighPrice:            upbit.DecimalFromFloat(c + 1),
+			LowPrice:             upbit.DecimalFromFloat(c - 1),
+			TradePrice:           upbit.DecimalFromFloat(c),
+			CandleAccTradeVolume: "10",
+		})
+	}
+	return cs
+}
+
+func reversed(cs []*upbit.Candle) []*upbit.Candle {
+	r := make([]*upbit.Candle, len(cs))
+	for i, c := range cs {
+		r[len(cs)-1-i] = c
+	}
+	return r
+}
+
+func equalSeries(t *testing.T, name string, got indicators.Series, want ...float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: len = %d, want %d", name, len(got), len(want))
+	}
+	for i := range want {
+		if math.IsNaN(want[i]) != math.IsNaN(got[i]) || !math.IsNaN(want[i]) && math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("%s = %v, want %v", name, got, want)
+			return
+		}
+	}
+}
+
+func TestSeries(t *testing.T) {
+	nan := math.NaN()
+	cs := candles(1, 2, 3, 4, 5, 4)
+
+	equalSeries(t, "SMA", indicators.SMA(cs, 3), nan, nan, 2, 3, 4, 13.0/3)
+	equalSeries(t, "EMA", indicators.EMA(cs, 3), nan, nan, 2, 3, 4, 4)
+	equalSeries(t, "OBV", indicators.OBV(cs), 0, 10, 20, 30, 40, 30)
+	// Gains 1, 1, 1, 1 then a loss of 1: (3*1+0)/4 over (3*0+1)/4.
+	equalSeries(t, "RSI", indicators.RSI(cs, 4), nan, nan, nan, nan, 100, 75)
+	// True ranges are 2 throughout.
+	equalSeries(t, "ATR", indicators.ATR(cs, 2), nan, 2, 2, 2, 2, 2)
+
+	_, upper, lower := indicators.Bollinger(cs, 2, 2)
+	equalSeries(t, "Bollinger upper", upper, nan, 2.5, 3.5, 4.5, 5.5, 5.5)
+	equalSeries(t, "Bollinger lower", lower, nan, 0.5, 1.5, 2.5, 3.5, 3.5)
+
+	// Newest first input gives newest first output.
+	equalSeries(t, "SMA reversed", indicators.SMA(reversed(cs), 3), 13.0/3, 4, 3, 2, nan, nan)
+}
+
+func TestStreamRevision(t *testing.T) {
+	cs := candles(10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 19, 18, 22, 25)
+	macd, signal, _ := indicators.MACD(cs, 3, 6, 4)
+
+	stream := indicators.NewMACDStream(3, 6, 4)
+	for i, c := range cs {
+		// Feed a provisional version of each candle first, as a candle in
+		// progress would be.
+		provisional := *c
+		provisional.TradePrice = c.TradePrice.Add("100")
+		stream.Update(&provisional)
+
+		v, ok := stream.Update(c)
+		if ok == math.IsNaN(signal[i]) {
+			t.Fatalf("candle %d: ok = %v, signal = %v", i, ok, signal[i])
+		}
+		if ok && (math.Abs(v.MACD-macd[i]) > 1e-9 || math.Abs(v.Signal-signal[i]) > 1e-9) {
+			t.Errorf("candle %d: stream = %+v, batch = %v %v", i, v, macd[i], signal[i])
+		}
+	}
+}
+
+func TestStreams(t *testing.T) {
+	cs := candles(10, 11, 12, 11, 13, 14, 12, 12, 15, 17, 16, 18)
+	rsi, atr, obv := indicators.RSI(cs, 4), indicators.ATR(cs, 3), indicators.OBV(cs)
+
+	streams := []struct {
+		name   string
+		batch  indicators.Series
+		update func(*upbit.Candle) (float64, bool)
+	}{
+		{"RSI", rsi, indicators.NewRSIStream(4).Update},
+		{"ATR", atr, indicators.NewATRStream(3).Update},
+		{"OBV", obv, indicators.NewOBVStream().Update},
+	}
+	for _, s := range streams {
+		for i, c := range cs {
+			provisional := *c
+			provisional.TradePrice = c.TradePrice.Add("5")
+			provisional.HighPrice = c.HighPrice.Add("5")
+			s.update(&provisional)
+
+			v, ok := s.update(c)
+			if ok == math.IsNaN(s.batch[i]) || ok && math.Abs(v-s.batch[i]) > 1e-9 {
+				t.Errorf("%s candle %d: stream = %v %v, batch = %v", s.name, i, v, ok, s.batch[i])
+			}
+		}
+	}
+}
+
+func TestInvalidPeriod(t *testing.T) {
+	nan := math.NaN()
+	cs := candles(1, 2, 3)
+
+	for _, period := range []int{0, -1} {
+		name := fmt.Sprintf("period %d", period)
+		equalSeries(t, "SMA "+name, indicators.SMA(cs, period), nan, nan, nan)
+		equalSeries(t, "EMA "+name, indicators.EMA(cs, period), nan, nan, nan)
+		equalSeries(t, "RSI "+name, indicators.RSI(cs, period), nan, nan, nan)
+		equalSeries(t, "ATR "+name, indicators.ATR(cs, period), nan, nan, nan)
+		_, upper, _ := indicators.Bollinger(cs, period, 2)
+		equalSeries(t, "Bollinger "+name, upper, nan, nan, nan)
+		macd, _, _ := indicators.MACD(cs, period, period, period)
+		equalSeries(t, "MACD "+name, macd, nan, nan, nan)
+
+		if v, ok := indicators.NewSMAStream(period).Update(cs[0]); ok {
+			t.Errorf("SMA stream of %s = %v", name, v)
+		}
+	}
+}
+
+func ExampleRSI() {
+	cs := candles(1, 2, 3, 2, 3, 4)
+	fmt.Printf("%.2f\n", indicators.RSI(cs, 3)[5])
+	// Output: 85.19
+}
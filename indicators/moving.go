@@ -0,0 +1,173 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+type smaState struct {
+	w window
+}
+
+func (s smaState) next(c *upbit.Candle) (state, []float64) {
+	s.w = s.w.push(closePrice(c))
+	if !s.w.full() {
+		return s, nil
+	}
+	return s, []float64{s.w.mean()}
+}
+
+// SMAStream is the simple moving average of closing prices over period
+// candles.
+type SMAStream struct{ s stream }
+
+func NewSMAStream(period int) *SMAStream {
+	return &SMAStream{stream{committed: smaState{window{size: period}}}}
+}
+
+func (i *SMAStream) Update(c *upbit.Candle) (float64, bool) {
+	return first(i.s.update(c))
+}
+
+// SMA returns the values of SMAStream for candles.
+func SMA(candles []*upbit.Candle, period int) Series {
+	return run(candles, smaState{window{size: period}}, 1)[0]
+}
+
+type emaState struct {
+	e ema
+}
+
+func (s emaState) next(c *upbit.Candle) (state, []float64) {
+	var ok bool
+	s.e, ok = s.e.push(closePrice(c))
+	if !ok {
+		return s, nil
+	}
+	return s, []float64{s.e.value}
+}
+
+// EMAStream is the exponential moving average of closing prices over period
+// candles, seeded with their simple average.
+type EMAStream struct{ s stream }
+
+func NewEMAStream(period int) *EMAStream {
+	return &EMAStream{stream{committed: emaState{newEMA(period)}}}
+}
+
+func (i *EMAStream) Update(c *upbit.Candle) (float64, bool) {
+	return first(i.s.update(c))
+}
+
+// EMA returns the values of EMAStream for candles.
+func EMA(candles []*upbit.Candle, period int) Series {
+	return run(candles, emaState{newEMA(period)}, 1)[0]
+}
+
+type MACDValue struct {
+	MACD      float64
+	Signal    float64
+	Histogram float64
+}
+
+type macdState struct {
+	fast, slow, signal ema
+}
+
+func (s macdState) next(c *upbit.Candle) (state, []float64) {
+	price := closePrice(c)
+	s.fast, _ = s.fast.push(price)
+
+	var ok bool
+	if s.slow, ok = s.slow.push(price); !ok {
+		return s, nil
+	}
+	macd := s.fast.value - s.slow.value
+	if s.signal, ok = s.signal.push(macd); !ok {
+		return s, nil
+	}
+	return s, []float64{macd, s.signal.value, macd - s.signal.value}
+}
+
+func newMACDState(fast, slow, signal int) macdState {
+	return macdState{newEMA(fast), newEMA(slow), newEMA(signal)}
+}
+
+// MACDStream is the difference of the fast and slow EMAs of closing prices,
+// its signal EMA and their difference, usually with periods 12, 26 and 9.
+type MACDStream struct{ s stream }
+
+func NewMACDStream(fast, slow, signal int) *MACDStream {
+	return &MACDStream{stream{committed: newMACDState(fast, slow, signal)}}
+}
+
+func (i *MACDStream) Update(c *upbit.Candle) (MACDValue, bool) {
+	v := i.s.update(c)
+	if v == nil {
+		return MACDValue{}, false
+	}
+	return MACDValue{v[0], v[1], v[2]}, true
+}
+
+// MACD returns the values of MACDStream for candles.
+func MACD(candles []*upbit.Candle, fast, slow, signal int) (macd, sig, histogram Series) {
+	s := run(candles, newMACDState(fast, slow, signal), 3)
+	return s[0], s[1], s[2]
+}
+
+type BollingerValue struct {
+	Middle float64
+	Upper  float64
+	Lower  float64
+}
+
+type bollingerState struct {
+	w window
+	k float64
+}
+
+func (s bollingerState) next(c *upbit.Candle) (state, []float64) {
+	s.w = s.w.push(closePrice(c))
+	if !s.w.full() {
+		return s, nil
+	}
+
+	mean := s.w.mean()
+	var variance float64
+	for _, v := range s.w.values {
+		variance += (v - mean) * (v - mean)
+	}
+	d := s.k * math.Sqrt(variance/float64(len(s.w.values)))
+	return s, []float64{mean, mean + d, mean - d}
+}
+
+// BollingerStream is the SMA of closing prices over period candles and the
+// bands k population standard deviations above and below it, usually 20
+// and 2.
+type BollingerStream struct{ s stream }
+
+func NewBollingerStream(period int, k float64) *BollingerStream {
+	return &BollingerStream{stream{committed: bollingerState{window{size: period}, k}}}
+}
+
+func (i *BollingerStream) Update(c *upbit.Candle) (BollingerValue, bool) {
+	v := i.s.update(c)
+	if v == nil {
+		return BollingerValue{}, false
+	}
+	return BollingerValue{v[0], v[1], v[2]}, true
+}
+
+// Bollinger returns the values of BollingerStream for candles.
+func Bollinger(candles []*upbit.Candle, period int, k float64) (middle, upper, lower Series) {
+	s := run(candles, bollingerState{window{size: period}, k}, 3)
+	return s[0], s[1], s[2]
+}
+
+func first(values []float64) (float64, bool) {
+	if values == nil {
+		return math.NaN(), false
+	}
+	return values[0], true
+}
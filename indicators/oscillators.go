@@ -0,0 +1,131 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+type rsiState struct {
+	prev    float64
+	started bool
+	gain    ema
+	loss    ema
+}
+
+func newRSIState(period int) rsiState {
+	return rsiState{gain: newWilder(period), loss: newWilder(period)}
+}
+
+func (s rsiState) next(c *upbit.Candle) (state, []float64) {
+	price := closePrice(c)
+	if !s.started {
+		s.prev, s.started = price, true
+		return s, nil
+	}
+
+	change := price - s.prev
+	s.prev = price
+
+	var ok bool
+	s.gain, ok = s.gain.push(math.Max(change, 0))
+	s.loss, _ = s.loss.push(math.Max(-change, 0))
+	if !ok {
+		return s, nil
+	}
+	if s.loss.value == 0 {
+		return s, []float64{100}
+	}
+	return s, []float64{100 - 100/(1+s.gain.value/s.loss.value)}
+}
+
+// RSIStream is Wilder's relative strength index of closing prices over
+// period candles, usually 14.
+type RSIStream struct{ s stream }
+
+func NewRSIStream(period int) *RSIStream {
+	return &RSIStream{stream{committed: newRSIState(period)}}
+}
+
+func (i *RSIStream) Update(c *upbit.Candle) (float64, bool) {
+	return first(i.s.update(c))
+}
+
+// RSI returns the values of RSIStream for candles.
+func RSI(candles []*upbit.Candle, period int) Series {
+	return run(candles, newRSIState(period), 1)[0]
+}
+
+type atrState struct {
+	prevClose float64
+	started   bool
+	tr        ema
+}
+
+func (s atrState) next(c *upbit.Candle) (state, []float64) {
+	high, low, close := c.HighPrice.Float64(), c.LowPrice.Float64(), closePrice(c)
+
+	tr := high - low
+	if s.started {
+		tr = math.Max(tr, math.Max(math.Abs(high-s.prevClose), math.Abs(low-s.prevClose)))
+	}
+	s.prevClose, s.started = close, true
+
+	var ok bool
+	if s.tr, ok = s.tr.push(tr); !ok {
+		return s, nil
+	}
+	return s, []float64{s.tr.value}
+}
+
+// ATRStream is Wilder's average true range over period candles, usually 14.
+type ATRStream struct{ s stream }
+
+func NewATRStream(period int) *ATRStream {
+	return &ATRStream{stream{committed: atrState{tr: newWilder(period)}}}
+}
+
+func (i *ATRStream) Update(c *upbit.Candle) (float64, bool) {
+	return first(i.s.update(c))
+}
+
+// ATR returns the values of ATRStream for candles.
+func ATR(candles []*upbit.Candle, period int) Series {
+	return run(candles, atrState{tr: newWilder(period)}, 1)[0]
+}
+
+type obvState struct {
+	prevClose float64
+	started   bool
+	obv       float64
+}
+
+func (s obvState) next(c *upbit.Candle) (state, []float64) {
+	price, volume := closePrice(c), c.CandleAccTradeVolume.Float64()
+	if s.started {
+		switch {
+		case price > s.prevClose:
+			s.obv += volume
+		case price < s.prevClose:
+			s.obv -= volume
+		}
+	}
+	s.prevClose, s.started = price, true
+	return s, []float64{s.obv}
+}
+
+// OBVStream is the on-balance volume, starting at 0 on the first candle.
+type OBVStream struct{ s stream }
+
+func NewOBVStream() *OBVStream {
+	return &OBVStream{stream{committed: obvState{}}}
+}
+
+func (i *OBVStream) Update(c *upbit.Candle) (float64, bool) {
+	return first(i.s.update(c))
+}
+
+// OBV returns the values of OBVStream for candles.
+func OBV(candles []*upbit.Candle) Series {
+	return run(candles, obvState{}, 1)[0]
+}
@@ -0,0 +1,422 @@
+// Package ledger keeps the accounts and orders of a simulated Upbit exchange
+// and settles orders against them like Upbit does: placing an order locks its
+// funds, fills move them between the quote and base accounts net of the fee,
+// and cancelling returns the rest. It is shared by upbit.PaperTrader and the
+// fake exchanges of the upbittest and backtest packages.
+//
+// Decimals are strings, the underlying type of upbit.Decimal, as package
+// upbit cannot be imported here. The JSON encoding of Account and Order is
+// the one of their upbit counterparts.
+package ledger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Sides, order types and states, as in package upbit.
+const (
+	sideBid = "bid"
+	sideAsk = "ask"
+
+	ordTypeLimit = "limit"
+	ordTypePrice = "price"
+
+	StateWait   = "wait"
+	StateDone   = "done"
+	StateCancel = "cancel"
+)
+
+// MinTotals are Upbit's minimum order totals by quote currency.
+var MinTotals = map[string]string{
+	"KRW":  "5000",
+	"BTC":  "0.00005",
+	"USDT": "0.5",
+	"SGD":  "1",
+	"IDR":  "10000",
+}
+
+// volumePlaces is the precision of volumes bought with a price order.
+const volumePlaces = 8
+
+// divisionPlaces is the precision of divisions, upbit.DivisionPrecision.
+const divisionPlaces = 16
+
+type Account struct {
+	Currency            string `json:"currency"`
+	Balance             string `json:"balance"`
+	Locked              string `json:"locked"`
+	AvgBuyPrice         string `json:"avg_buy_price"`
+	AvgBuyPriceModified bool   `json:"avg_buy_price_modified"`
+	UnitCurrency        string `json:"unit_currency"`
+}
+
+type Order struct {
+	UUID            string    `json:"uuid"`
+	Side            string    `json:"side"`
+	OrdType         string    `json:"ord_type"`
+	Price           string    `json:"price"`
+	State           string    `json:"state"`
+	Market          string    `json:"market"`
+	CreatedAt       time.Time `json:"created_at"`
+	Volume          string    `json:"volume"`
+	RemainingVolume string    `json:"remaining_volume"`
+	ReservedFee     string    `json:"reserved_fee"`
+	RemainingFee    string    `json:"remaining_fee"`
+	PaidFee         string    `json:"paid_fee"`
+	Locked          string    `json:"locked"`
+	ExecutedVolume  string    `json:"executed_volume"`
+	TradesCount     int       `json:"trades_count"`
+
+	Identifier string `json:"identifier,omitempty"`
+	// Fee is the fee rate of the order.
+	Fee string `json:"fee"`
+	// Funds is what is left to spend of a price order.
+	Funds string `json:"funds,omitempty"`
+}
+
+// Level is liquidity at a price. An empty Size is liquidity without limit.
+type Level struct {
+	Price string
+	Size  string
+}
+
+// Request is an order to place. It must be a valid limit, price or market
+// order.
+type Request struct {
+	Market     string
+	Side       string
+	OrdType    string
+	Price      string
+	Volume     string
+	Identifier string
+	// Fee is the fee rate of the order.
+	Fee string
+}
+
+// Query selects orders like Upbit's order list. With no State, States,
+// UUIDs or Identifiers it selects the waiting orders.
+type Query struct {
+	Market      string
+	State       string
+	States      []string
+	UUIDs       []string
+	Identifiers []string
+	// Page starts at 1. Limit is at most 100, the default.
+	Page  int
+	Limit int
+	// OrderBy is "asc" for the oldest first, otherwise the newest first.
+	OrderBy string
+}
+
+// InsufficientError is returned by Place when the available balance does not
+// cover an order.
+type InsufficientError struct {
+	Currency            string
+	Required, Available string
+}
+
+func (e *InsufficientError) Error() string {
+	return fmt.Sprintf("%s %s required, %s available", e.Required, e.Currency, e.Available)
+}
+
+// Ledger is the state of a simulated exchange. It is not safe for concurrent
+// use.
+type Ledger struct {
+	Accounts map[string]*Account `json:"accounts"`
+	// Orders are all orders placed, oldest first.
+	Orders []*Order `json:"orders"`
+
+	// UnitCurrency is the UnitCurrency of accounts the ledger opens.
+	UnitCurrency string `json:"-"`
+	// OnFill, if set, is called with every execution. fee is paid in the
+	// quote currency of the market.
+	OnFill func(o *Order, price, volume, fee string) `json:"-"`
+}
+
+// New returns an empty Ledger whose accounts are valued in unitCurrency.
+func New(unitCurrency string) *Ledger {
+	return &Ledger{Accounts: map[string]*Account{}, UnitCurrency: unitCurrency}
+}
+
+// Account returns the account of currency, opening an empty one if there is
+// none.
+func (l *Ledger) Account(currency string) *Account {
+	if l.Accounts == nil {
+		l.Accounts = map[string]*Account{}
+	}
+	a, ok := l.Accounts[currency]
+	if !ok {
+		a = &Account{Currency: currency, Balance: "0", Locked: "0", AvgBuyPrice: "0", UnitCurrency: l.UnitCurrency}
+		l.Accounts[currency] = a
+	}
+	return a
+}
+
+// Holdings returns a copy of the accounts with a balance, by currency.
+func (l *Ledger) Holdings() []*Account {
+	accounts := []*Account{}
+	for _, a := range l.Accounts {
+		if dec(a.Balance).Sign() == 0 && dec(a.Locked).Sign() == 0 {
+			continue
+		}
+		account := *a
+		accounts = append(accounts, &account)
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Currency < accounts[j].Currency })
+	return accounts
+}
+
+// Find returns the order of uuid or, if uuid is empty, of identifier, or
+// nil.
+func (l *Ledger) Find(uuid, identifier string) *Order {
+	for _, o := range l.Orders {
+		if uuid != "" && o.UUID == uuid || uuid == "" && identifier != "" && o.Identifier == identifier {
+			return o
+		}
+	}
+	return nil
+}
+
+// List returns the page of orders q selects.
+func (l *Ledger) List(q *Query) []*Order {
+	states := q.States
+	if q.State != "" {
+		states = []string{q.State}
+	}
+	if len(states) == 0 && len(q.UUIDs) == 0 && len(q.Identifiers) == 0 {
+		states = []string{StateWait}
+	}
+	page, limit := q.Page, q.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 100
+	}
+
+	var matched []*Order
+	for _, o := range l.Orders {
+		if q.Market != "" && o.Market != q.Market ||
+			len(states) > 0 && !contains(states, o.State) ||
+			len(q.UUIDs) > 0 && !contains(q.UUIDs, o.UUID) ||
+			len(q.Identifiers) > 0 && !contains(q.Identifiers, o.Identifier) {
+			continue
+		}
+		matched = append(matched, o)
+	}
+	if !strings.EqualFold(q.OrderBy, "asc") {
+		for i, j := 0, len(matched)-1; i < j; i, j = i+1, j-1 {
+			matched[i], matched[j] = matched[j], matched[i]
+		}
+	}
+
+	orders := []*Order{}
+	for i := (page - 1) * limit; i < len(matched) && i < page*limit; i++ {
+		orders = append(orders, matched[i])
+	}
+	return orders
+}
+
+// Place opens a waiting order of req at now and locks its funds: the total
+// and the fee of a bid, the volume of an ask. It returns an
+// *InsufficientError if the available balance is short.
+func (l *Ledger) Place(req *Request, now time.Time) (*Order, error) {
+	quote, base, err := splitMarket(req.Market)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &Order{
+		UUID:            uuid.New().String(),
+		Side:            req.Side,
+		OrdType:         req.OrdType,
+		Price:           req.Price,
+		State:           StateWait,
+		Market:          req.Market,
+		CreatedAt:       now,
+		Volume:          req.Volume,
+		RemainingVolume: req.Volume,
+		ReservedFee:     "0",
+		RemainingFee:    "0",
+		PaidFee:         "0",
+		Locked:          "0",
+		ExecutedVolume:  "0",
+		Identifier:      req.Identifier,
+		Fee:             req.Fee,
+	}
+
+	currency, required := base, dec(o.Volume)
+	if o.Side == sideBid {
+		total := dec(o.Price)
+		if o.OrdType == ordTypeLimit {
+			total = total.Mul(dec(o.Volume))
+		} else {
+			o.Funds = str(total)
+		}
+		fee := total.Mul(dec(o.Fee))
+		o.ReservedFee, o.RemainingFee = str(fee), str(fee)
+		currency, required = quote, total.Add(fee)
+	}
+
+	account := l.Account(currency)
+	if balance := dec(account.Balance); balance.LessThan(required) {
+		return nil, &InsufficientError{Currency: currency, Required: str(required), Available: account.Balance}
+	}
+	account.Balance = str(dec(account.Balance).Sub(required))
+	account.Locked = str(dec(account.Locked).Add(required))
+	o.Locked = str(required)
+	l.Orders = append(l.Orders, o)
+	return o, nil
+}
+
+// Match fills o against levels, best first, and returns what is left of
+// them. Limit orders fill at their own price if maker, like a waiting order
+// the market crossed, and at the prices of the levels otherwise, like a
+// taker. A limit order that is not filled waits; the rest of market and
+// price orders is cancelled.
+func (l *Ledger) Match(o *Order, levels []Level, maker bool) []Level {
+	levels = append([]Level(nil), levels...)
+	for len(levels) > 0 {
+		level := &levels[0]
+		price := dec(level.Price)
+		if o.OrdType == ordTypeLimit {
+			limit := dec(o.Price)
+			if o.Side == sideBid && price.GreaterThan(limit) || o.Side == sideAsk && price.LessThan(limit) {
+				break
+			}
+			if maker {
+				price = limit
+			}
+		}
+
+		v := dec(o.RemainingVolume)
+		if o.OrdType == ordTypePrice {
+			v = dec(o.Funds).DivRound(price, divisionPlaces).Truncate(volumePlaces)
+		}
+		if level.Size != "" && dec(level.Size).LessThan(v) {
+			v = dec(level.Size)
+		}
+		if v.Sign() <= 0 {
+			break
+		}
+
+		l.fill(o, price, v)
+		if level.Size != "" {
+			level.Size = str(dec(level.Size).Sub(v))
+			if dec(level.Size).Sign() == 0 {
+				levels = levels[1:]
+			}
+		}
+		if o.OrdType != ordTypePrice && dec(o.RemainingVolume).Sign() == 0 || level.Size == "" {
+			break
+		}
+	}
+
+	switch {
+	case o.OrdType != ordTypePrice && dec(o.RemainingVolume).Sign() == 0,
+		o.OrdType == ordTypePrice && dec(o.Funds).Sign() == 0:
+		o.State = StateDone
+		o.RemainingFee = "0"
+	case o.OrdType != ordTypeLimit:
+		// Like Upbit, a market order left with dust or without liquidity
+		// ends cancelled with the rest returned.
+		l.Cancel(o)
+	}
+	return levels
+}
+
+// Cancel cancels o and returns what it still locks to the available
+// balance.
+func (l *Ledger) Cancel(o *Order) {
+	quote, base, _ := splitMarket(o.Market)
+	currency := quote
+	if o.Side == sideAsk {
+		currency = base
+	}
+	a := l.Account(currency)
+	a.Locked = str(dec(a.Locked).Sub(dec(o.Locked)))
+	a.Balance = str(dec(a.Balance).Add(dec(o.Locked)))
+	o.Locked = "0"
+	o.RemainingFee = "0"
+	o.State = StateCancel
+}
+
+// fill executes volume of o at price and settles the accounts.
+func (l *Ledger) fill(o *Order, price, volume decimal.Decimal) {
+	quoteCurrency, baseCurrency, _ := splitMarket(o.Market)
+	quote, base := l.Account(quoteCurrency), l.Account(baseCurrency)
+	notional := price.Mul(volume)
+	rate := dec(o.Fee)
+	fee := notional.Mul(rate)
+
+	if o.Side == sideBid {
+		// What was locked for this volume; a limit bid filled below its
+		// price gets the difference back.
+		locked := notional.Add(fee)
+		if o.OrdType == ordTypeLimit {
+			locked = dec(o.Price).Mul(volume).Mul(rate.Add(decimal.New(1, 0)))
+		} else {
+			o.Funds = str(dec(o.Funds).Sub(notional))
+		}
+		quote.Locked = str(dec(quote.Locked).Sub(locked))
+		quote.Balance = str(dec(quote.Balance).Add(locked.Sub(notional).Sub(fee)))
+		o.Locked = str(dec(o.Locked).Sub(locked))
+		o.RemainingFee = str(dec(o.RemainingFee).Sub(fee))
+
+		held := dec(base.Balance).Add(dec(base.Locked))
+		base.AvgBuyPrice = str(dec(base.AvgBuyPrice).Mul(held).Add(notional).DivRound(held.Add(volume), divisionPlaces))
+		base.Balance = str(dec(base.Balance).Add(volume))
+	} else {
+		base.Locked = str(dec(base.Locked).Sub(volume))
+		quote.Balance = str(dec(quote.Balance).Add(notional).Sub(fee))
+		o.Locked = str(dec(o.Locked).Sub(volume))
+	}
+
+	if o.RemainingVolume != "" {
+		o.RemainingVolume = str(dec(o.RemainingVolume).Sub(volume))
+	}
+	o.ExecutedVolume = str(dec(o.ExecutedVolume).Add(volume))
+	o.PaidFee = str(dec(o.PaidFee).Add(fee))
+	o.TradesCount++
+
+	if l.OnFill != nil {
+		l.OnFill(o, str(price), str(volume), str(fee))
+	}
+}
+
+// splitMarket returns the quote and base currencies of a market code such as
+// KRW-BTC.
+func splitMarket(market string) (quote, base string, err error) {
+	i := strings.IndexByte(market, '-')
+	if i <= 0 || i == len(market)-1 {
+		return "", "", fmt.Errorf("ledger: invalid market %q", market)
+	}
+	return market[:i], market[i+1:], nil
+}
+
+// dec parses s, which callers validate; an empty s is zero.
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func dec(s string) decimal.Decimal {
+	if s == "" {
+		return decimal.Zero
+	}
+	return decimal.RequireFromString(s)
+}
+
+func str(d decimal.Decimal) string {
+	return d.String()
+}
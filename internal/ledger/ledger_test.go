@@ -0,0 +1,142 @@
+package ledger_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/investing-kr/go-upbit/internal/ledger"
+	"github.com/shopspring/decimal"
+)
+
+func equal(a, b string) bool {
+	return decimal.RequireFromString(a).Equal(decimal.RequireFromString(b))
+}
+
+func TestLedger(t *testing.T) {
+	l := ledger.New("KRW")
+	l.Account("KRW").Balance = "1000000"
+	var fills []string
+	l.OnFill = func(o *ledger.Order, price, volume, fee string) {
+		fills = append(fills, price)
+	}
+
+	bid, err := l.Place(&ledger.Request{Market: "KRW-BTC", Side: "bid", OrdType: "limit", Price: "50000000", Volume: "0.01", Fee: "0.0005"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if krw := l.Account("KRW"); !equal(krw.Balance, "499750") || !equal(krw.Locked, "500250") {
+		t.Errorf("KRW after placing = %+v", krw)
+	}
+
+	// A taker bid fills below its price and gets the difference back.
+	levels := l.Match(bid, []ledger.Level{{Price: "49000000", Size: "0.004"}, {Price: "50000000", Size: "1"}}, false)
+	if bid.State != ledger.StateDone || !equal(bid.PaidFee, "248") || len(fills) != 2 {
+		t.Errorf("bid = %+v after %d fills", bid, len(fills))
+	}
+	if len(levels) != 1 || !equal(levels[0].Size, "0.994") {
+		t.Errorf("levels left = %+v", levels)
+	}
+	if krw := l.Account("KRW"); !equal(krw.Balance, "503752") || !equal(krw.Locked, "0") {
+		t.Errorf("KRW after filling = %+v", krw)
+	}
+
+	_, err = l.Place(&ledger.Request{Market: "KRW-BTC", Side: "ask", OrdType: "market", Volume: "1", Fee: "0.0005"}, time.Now())
+	var short *ledger.InsufficientError
+	if !errors.As(err, &short) || short.Currency != "BTC" {
+		t.Errorf("oversized ask err = %v, want an InsufficientError for BTC", err)
+	}
+
+	// A waiting ask crossed by a trade fills at its own price as a maker.
+	ask, err := l.Place(&ledger.Request{Market: "KRW-BTC", Side: "ask", OrdType: "limit", Price: "60000000", Volume: "0.01", Fee: "0.0005"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Match(ask, []ledger.Level{{Price: "55000000", Size: "1"}}, false)
+	if ask.State != ledger.StateWait {
+		t.Fatalf("uncrossed ask = %+v", ask)
+	}
+	l.Match(ask, []ledger.Level{{Price: "61000000", Size: "0.004"}}, true)
+	if !equal(ask.RemainingVolume, "0.006") || !equal(fills[2], "60000000") {
+		t.Errorf("ask = %+v, filled at %v", ask, fills[2])
+	}
+
+	l.Cancel(ask)
+	if btc := l.Account("BTC"); ask.State != ledger.StateCancel || !equal(btc.Balance, "0.006") || !equal(btc.Locked, "0") {
+		t.Errorf("cancelled ask = %+v, BTC = %+v", ask, btc)
+	}
+	if l.Find(ask.UUID, "") != ask {
+		t.Errorf("Find(%s) did not return the ask", ask.UUID)
+	}
+
+	// The ledger round trips through JSON, as PaperTrader saves it.
+	b, err := json.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := ledger.New("KRW")
+	if err := json.Unmarshal(b, restored); err != nil {
+		t.Fatal(err)
+	}
+	if len(restored.Orders) != 2 || len(restored.Holdings()) != 2 {
+		t.Errorf("restored ledger = %+v", restored)
+	}
+}
+
+func TestPriceOrderDust(t *testing.T) {
+	l := ledger.New("KRW")
+	l.Account("KRW").Balance = "20000"
+
+	// 10000 KRW buys 0.00033333 at 30000000; the rest cannot buy another
+	// unit of volume and is returned.
+	o, err := l.Place(&ledger.Request{Market: "KRW-BTC", Side: "bid", OrdType: "price", Price: "10000", Fee: "0"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Match(o, []ledger.Level{{Price: "30000000", Size: "1"}}, false)
+	if o.State != ledger.StateCancel || !equal(o.ExecutedVolume, "0.00033333") {
+		t.Errorf("price order = %+v", o)
+	}
+	if krw := l.Account("KRW"); !equal(krw.Balance, "10000.1") || !equal(krw.Locked, "0") {
+		t.Errorf("KRW = %+v", krw)
+	}
+}
+
+func TestList(t *testing.T) {
+	l := ledger.New("KRW")
+	l.Account("KRW").Balance = "1000000"
+	var placed []*ledger.Order
+	for i, id := range []string{"a", "b", "c"} {
+		o, err := l.Place(&ledger.Request{Market: "KRW-BTC", Side: "bid", OrdType: "limit", Price: "1000", Volume: "1", Identifier: id, Fee: "0"}, time.Unix(int64(i), 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		placed = append(placed, o)
+	}
+	l.Cancel(placed[1])
+
+	ids := func(orders []*ledger.Order) string {
+		var s string
+		for _, o := range orders {
+			s += o.Identifier
+		}
+		return s
+	}
+	for _, tt := range []struct {
+		q    ledger.Query
+		want string
+	}{
+		{ledger.Query{}, "ca"},
+		{ledger.Query{OrderBy: "asc"}, "ac"},
+		{ledger.Query{State: ledger.StateCancel}, "b"},
+		{ledger.Query{States: []string{ledger.StateWait, ledger.StateCancel}, Limit: 2, Page: 2}, "a"},
+		{ledger.Query{Identifiers: []string{"a", "b"}}, "ba"},
+		{ledger.Query{UUIDs: []string{placed[2].UUID}}, "c"},
+		{ledger.Query{Market: "KRW-ETH"}, ""},
+	} {
+		if got := ids(l.List(&tt.q)); got != tt.want {
+			t.Errorf("List(%+v) = %q, want %q", tt.q, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Package ledgerconv converts between the types of package upbit and those
+// of package ledger, for the packages other than upbit built on the ledger.
+// Package upbit keeps its own copy in paper.go, as it cannot import this
+// package.
+package ledgerconv
+
+import (
+	"errors"
+
+	"github.com/investing-kr/go-upbit"
+	"github.com/investing-kr/go-upbit/internal/ledger"
+)
+
+// Request returns the ledger request of req paying fee.
+func Request(req *upbit.OrderRequest, fee upbit.Decimal) *ledger.Request {
+	return &ledger.Request{
+		Market:     req.Market,
+		Side:       req.Side,
+		OrdType:    req.OrdType,
+		Price:      string(req.Price),
+		Volume:     string(req.Volume),
+		Identifier: req.Identifier,
+		Fee:        string(fee),
+	}
+}
+
+// Error returns err of placing req, an *upbit.OrderValidationError for a
+// short balance.
+func Error(req *upbit.OrderRequest, err error) error {
+	var short *ledger.InsufficientError
+	if !errors.As(err, &short) {
+		return err
+	}
+	return &upbit.OrderValidationError{
+		Reason:  upbit.ErrInsufficientBalance,
+		Market:  req.Market,
+		Side:    req.Side,
+		Message: short.Error(),
+	}
+}
+
+// Query returns the ledger query of opt, which may be nil.
+func Query(opt *upbit.OrderListOptions) *ledger.Query {
+	if opt == nil {
+		return &ledger.Query{}
+	}
+	return &ledger.Query{
+		Market:      opt.Market,
+		State:       opt.State,
+		States:      opt.States,
+		UUIDs:       opt.UUIDs,
+		Identifiers: opt.Identifiers,
+		Page:        opt.Page,
+		Limit:       opt.Limit,
+		OrderBy:     opt.OrderBy,
+	}
+}
+
+// Orders converts orders, never returning nil.
+func Orders(orders []*ledger.Order) []*upbit.Order {
+	converted := make([]*upbit.Order, len(orders))
+	for i, o := range orders {
+		converted[i] = Order(o)
+	}
+	return converted
+}
+
+func Order(o *ledger.Order) *upbit.Order {
+	return &upbit.Order{
+		UUID:            o.UUID,
+		Side:            o.Side,
+		OrdType:         o.OrdType,
+		Price:           upbit.Decimal(o.Price),
+		State:           o.State,
+		Market:          o.Market,
+		CreatedAt:       o.CreatedAt,
+		Volume:          upbit.Decimal(o.Volume),
+		RemainingVolume: upbit.Decimal(o.RemainingVolume),
+		ReservedFee:     upbit.Decimal(o.ReservedFee),
+		RemainingFee:    upbit.Decimal(o.RemainingFee),
+		PaidFee:         upbit.Decimal(o.PaidFee),
+		Locked:          upbit.Decimal(o.Locked),
+		ExecutedVolume:  upbit.Decimal(o.ExecutedVolume),
+		TradesCount:     o.TradesCount,
+	}
+}
+
+func Account(a *ledger.Account) *upbit.Account {
+	return &upbit.Account{
+		Currency:            a.Currency,
+		Balance:             upbit.Decimal(a.Balance),
+		Locked:              upbit.Decimal(a.Locked),
+		AvgBuyPrice:         upbit.Decimal(a.AvgBuyPrice),
+		AvgBuyPriceModified: a.AvgBuyPriceModified,
+		UnitCurrency:        a.UnitCurrency,
+	}
+}
+
+// Holdings returns the accounts of l with a balance, by currency.
+func Holdings(l *ledger.Ledger) []*upbit.Account {
+	accounts := []*upbit.Account{}
+	for _, a := range l.Holdings() {
+		accounts = append(accounts, Account(a))
+	}
+	return accounts
+}
+
+func Levels(levels []upbit.BookLevel) []ledger.Level {
+	converted := make([]ledger.Level, len(levels))
+	for i, l := range levels {
+		converted[i] = ledger.Level{Price: string(l.Price), Size: string(l.Size)}
+	}
+	return converted
+}
+
+func BookLevels(levels []ledger.Level) []upbit.BookLevel {
+	converted := make([]upbit.BookLevel, len(levels))
+	for i, l := range levels {
+		converted[i] = upbit.BookLevel{Price: upbit.Decimal(l.Price), Size: upbit.Decimal(l.Size)}
+	}
+	return converted
+}
@@ -1,267 +1,275 @@
+// Code generated by go run ./cmd/marketcodes; DO NOT EDIT.
+
 package upbit
 
+// Markets listed on Upbit KR when this file was generated. Use
+// MarketRegistry to follow listings at run time.
 const (
-	KRW_BTC   = "KRW-BTC"
-	KRW_ETH   = "KRW-ETH"
-	BTC_ETH   = "BTC-ETH"
-	BTC_LTC   = "BTC-LTC"
-	BTC_STRAT = "BTC-STRAT"
-	BTC_XRP   = "BTC-XRP"
-	BTC_ETC   = "BTC-ETC"
-	BTC_OMG   = "BTC-OMG"
+	BTC_ADA   = "BTC-ADA"
+	BTC_ADX   = "BTC-ADX"
+	BTC_AERGO = "BTC-AERGO"
+	BTC_AHT   = "BTC-AHT"
+	BTC_ANKR  = "BTC-ANKR"
+	BTC_ANT   = "BTC-ANT"
+	BTC_ARDR  = "BTC-ARDR"
+	BTC_ARK   = "BTC-ARK"
+	BTC_ATOM  = "BTC-ATOM"
+	BTC_BAT   = "BTC-BAT"
+	BTC_BCH   = "BTC-BCH"
+	BTC_BFT   = "BTC-BFT"
+	BTC_BLK   = "BTC-BLK"
+	BTC_BNT   = "BTC-BNT"
+	BTC_BORA  = "BTC-BORA"
+	BTC_BSV   = "BTC-BSV"
+	BTC_BTM   = "BTC-BTM"
+	BTC_BTS   = "BTC-BTS"
+	BTC_BTT   = "BTC-BTT"
+	BTC_BTU   = "BTC-BTU"
+	BTC_BURST = "BTC-BURST"
+	BTC_CHR   = "BTC-CHR"
+	BTC_CHZ   = "BTC-CHZ"
+	BTC_CMCT  = "BTC-CMCT"
+	BTC_CRO   = "BTC-CRO"
+	BTC_CRW   = "BTC-CRW"
+	BTC_CTXC  = "BTC-CTXC"
 	BTC_CVC   = "BTC-CVC"
+	BTC_DAD   = "BTC-DAD"
+	BTC_DAI   = "BTC-DAI"
+	BTC_DCR   = "BTC-DCR"
+	BTC_DENT  = "BTC-DENT"
 	BTC_DGB   = "BTC-DGB"
-	BTC_PAY   = "BTC-PAY"
-	BTC_SC    = "BTC-SC"
-	BTC_SNT   = "BTC-SNT"
-	BTC_WAVES = "BTC-WAVES"
-	BTC_NMR   = "BTC-NMR"
+	BTC_DKA   = "BTC-DKA"
+	BTC_DMT   = "BTC-DMT"
+	BTC_DNT   = "BTC-DNT"
+	BTC_DOGE  = "BTC-DOGE"
+	BTC_DTA   = "BTC-DTA"
+	BTC_EDR   = "BTC-EDR"
+	BTC_ELF   = "BTC-ELF"
+	BTC_EMC2  = "BTC-EMC2"
+	BTC_ENG   = "BTC-ENG"
+	BTC_ENJ   = "BTC-ENJ"
+	BTC_EOS   = "BTC-EOS"
+	BTC_ETC   = "BTC-ETC"
+	BTC_ETH   = "BTC-ETH"
+	BTC_EXP   = "BTC-EXP"
+	BTC_FSN   = "BTC-FSN"
+	BTC_FX    = "BTC-FX"
 	BTC_GBYTE = "BTC-GBYTE"
-	BTC_XEM   = "BTC-XEM"
-	BTC_LBC   = "BTC-LBC"
-	BTC_QTUM  = "BTC-QTUM"
+	// Deprecated: BTC-GNT is no longer listed.
 	BTC_GNT   = "BTC-GNT"
-	BTC_NXT   = "BTC-NXT"
-	BTC_BAT   = "BTC-BAT"
+	BTC_GO    = "BTC-GO"
+	BTC_GOM2  = "BTC-GOM2"
+	BTC_GRS   = "BTC-GRS"
+	BTC_GTO   = "BTC-GTO"
+	BTC_HBD   = "BTC-HBD"
+	BTC_HIVE  = "BTC-HIVE"
+	BTC_IGNIS = "BTC-IGNIS"
+	BTC_IOST  = "BTC-IOST"
+	BTC_IOTX  = "BTC-IOTX"
+	BTC_ITAM  = "BTC-ITAM"
+	BTC_JNT   = "BTC-JNT"
+	BTC_JST   = "BTC-JST"
+	BTC_KAVA  = "BTC-KAVA"
+	BTC_KMD   = "BTC-KMD"
+	BTC_LAMB  = "BTC-LAMB"
+	BTC_LBA   = "BTC-LBA"
+	BTC_LBC   = "BTC-LBC"
+	BTC_LINK  = "BTC-LINK"
+	BTC_LOOM  = "BTC-LOOM"
+	BTC_LRC   = "BTC-LRC"
 	BTC_LSK   = "BTC-LSK"
-	BTC_RDD   = "BTC-RDD"
-	BTC_STEEM = "BTC-STEEM"
-	BTC_DCR   = "BTC-DCR"
-	BTC_DOGE  = "BTC-DOGE"
-	BTC_BNT   = "BTC-BNT"
-	BTC_XLM   = "BTC-XLM"
+	BTC_LTC   = "BTC-LTC"
+	BTC_LUNA  = "BTC-LUNA"
+	BTC_MANA  = "BTC-MANA"
 	BTC_MCO   = "BTC-MCO"
-	BTC_ARDR  = "BTC-ARDR"
-	BTC_KMD   = "BTC-KMD"
-	BTC_ARK   = "BTC-ARK"
-	BTC_ADX   = "BTC-ADX"
-	BTC_SYS   = "BTC-SYS"
-	BTC_ANT   = "BTC-ANT"
-	BTC_XDN   = "BTC-XDN"
-	BTC_STORJ = "BTC-STORJ"
-	BTC_QRL   = "BTC-QRL"
+	BTC_MED   = "BTC-MED"
+	BTC_META  = "BTC-META"
+	BTC_MFT   = "BTC-MFT"
+	BTC_MKR   = "BTC-MKR"
+	BTC_MLK   = "BTC-MLK"
+	BTC_MOC   = "BTC-MOC"
+	BTC_MTL   = "BTC-MTL"
+	BTC_NCASH = "BTC-NCASH"
+	BTC_NKN   = "BTC-NKN"
+	BTC_NMR   = "BTC-NMR"
+	BTC_NPXS  = "BTC-NPXS"
 	BTC_NXS   = "BTC-NXS"
-	BTC_GRS   = "BTC-GRS"
-	BTC_VTC   = "BTC-VTC"
+	BTC_NXT   = "BTC-NXT"
+	BTC_OBSR  = "BTC-OBSR"
+	BTC_OGN   = "BTC-OGN"
+	BTC_OMG   = "BTC-OMG"
+	BTC_ORBS  = "BTC-ORBS"
+	BTC_OST   = "BTC-OST"
+	BTC_PAX   = "BTC-PAX"
+	BTC_PAY   = "BTC-PAY"
+	BTC_PCI   = "BTC-PCI"
+	BTC_PI    = "BTC-PI"
+	BTC_PMA   = "BTC-PMA"
+	BTC_POLY  = "BTC-POLY"
+	BTC_POWR  = "BTC-POWR"
+	BTC_PRO   = "BTC-PRO"
+	BTC_PXL   = "BTC-PXL"
+	BTC_QNT   = "BTC-QNT"
+	BTC_QRL   = "BTC-QRL"
+	BTC_QTUM  = "BTC-QTUM"
+	BTC_RADS  = "BTC-RADS"
+	BTC_RCN   = "BTC-RCN"
+	BTC_RDD   = "BTC-RDD"
 	BTC_REP   = "BTC-REP"
+	BTC_RFR   = "BTC-RFR"
+	BTC_RINGX = "BTC-RINGX"
 	BTC_RLC   = "BTC-RLC"
-	BTC_EMC2  = "BTC-EMC2"
-	BTC_EXP   = "BTC-EXP"
-	BTC_BURST = "BTC-BURST"
-	BTC_BLK   = "BTC-BLK"
-	BTC_RADS  = "BTC-RADS"
-	USDT_BTC  = "USDT-BTC"
-	USDT_ETH  = "USDT-ETH"
-	USDT_LTC  = "USDT-LTC"
-	USDT_XRP  = "USDT-XRP"
-	USDT_ETC  = "USDT-ETC"
-	KRW_NEO   = "KRW-NEO"
-	KRW_MTL   = "KRW-MTL"
-	KRW_LTC   = "KRW-LTC"
-	KRW_STRAT = "KRW-STRAT"
-	KRW_XRP   = "KRW-XRP"
-	KRW_ETC   = "KRW-ETC"
-	KRW_OMG   = "KRW-OMG"
-	KRW_SNT   = "KRW-SNT"
-	KRW_WAVES = "KRW-WAVES"
-	KRW_XEM   = "KRW-XEM"
-	KRW_QTUM  = "KRW-QTUM"
-	KRW_GNT   = "KRW-GNT"
-	KRW_LSK   = "KRW-LSK"
-	KRW_STEEM = "KRW-STEEM"
-	KRW_XLM   = "KRW-XLM"
-	KRW_ARDR  = "KRW-ARDR"
-	KRW_KMD   = "KRW-KMD"
-	KRW_ARK   = "KRW-ARK"
-	KRW_STORJ = "KRW-STORJ"
-	KRW_GRS   = "KRW-GRS"
-	KRW_VTC   = "KRW-VTC"
-	KRW_REP   = "KRW-REP"
-	KRW_EMC2  = "KRW-EMC2"
-	KRW_ADA   = "KRW-ADA"
-	BTC_ADA   = "BTC-ADA"
-	BTC_MANA  = "BTC-MANA"
-	USDT_OMG  = "USDT-OMG"
-	KRW_SBD   = "KRW-SBD"
+	BTC_RVN   = "BTC-RVN"
 	BTC_SBD   = "BTC-SBD"
-	BTC_RCN   = "BTC-RCN"
-	BTC_VIB   = "BTC-VIB"
-	KRW_POWR  = "KRW-POWR"
-	BTC_POWR  = "BTC-POWR"
-	KRW_BTG   = "KRW-BTG"
-	USDT_ADA  = "USDT-ADA"
-	BTC_ENG   = "BTC-ENG"
-	BTC_DNT   = "BTC-DNT"
-	BTC_IGNIS = "BTC-IGNIS"
+	BTC_SC    = "BTC-SC"
+	BTC_SNT   = "BTC-SNT"
+	BTC_SOLVE = "BTC-SOLVE"
+	BTC_SPC   = "BTC-SPC"
+	BTC_SPND  = "BTC-SPND"
 	BTC_SRN   = "BTC-SRN"
-	BTC_ZRX   = "BTC-ZRX"
-	BTC_VEE   = "BTC-VEE"
+	BTC_STEEM = "BTC-STEEM"
+	BTC_STMX  = "BTC-STMX"
+	BTC_STORJ = "BTC-STORJ"
+	BTC_STPT  = "BTC-STPT"
+	// Deprecated: BTC-STRAT is no longer listed.
+	BTC_STRAT = "BTC-STRAT"
+	BTC_SYS   = "BTC-SYS"
 	BTC_TRX   = "BTC-TRX"
+	BTC_TSHP  = "BTC-TSHP"
+	BTC_TTC   = "BTC-TTC"
 	BTC_TUSD  = "BTC-TUSD"
-	BTC_LRC   = "BTC-LRC"
-	KRW_ICX   = "KRW-ICX"
+	BTC_UPP   = "BTC-UPP"
+	BTC_VDX   = "BTC-VDX"
+	BTC_VEE   = "BTC-VEE"
+	BTC_VET   = "BTC-VET"
+	BTC_VIB   = "BTC-VIB"
+	BTC_VITE  = "BTC-VITE"
+	BTC_VTC   = "BTC-VTC"
+	BTC_WAVES = "BTC-WAVES"
+	BTC_WAXP  = "BTC-WAXP"
+	BTC_XDN   = "BTC-XDN"
+	BTC_XEM   = "BTC-XEM"
+	BTC_XLM   = "BTC-XLM"
+	BTC_XNK   = "BTC-XNK"
+	BTC_XRP   = "BTC-XRP"
+	BTC_XTZ   = "BTC-XTZ"
+	BTC_ZIL   = "BTC-ZIL"
+	BTC_ZRX   = "BTC-ZRX"
+	KRW_ADA   = "KRW-ADA"
+	KRW_ADX   = "KRW-ADX"
+	KRW_AERGO = "KRW-AERGO"
+	KRW_AHT   = "KRW-AHT"
+	KRW_ANKR  = "KRW-ANKR"
+	KRW_ARDR  = "KRW-ARDR"
+	KRW_ARK   = "KRW-ARK"
+	KRW_ATOM  = "KRW-ATOM"
+	KRW_BAT   = "KRW-BAT"
+	KRW_BCH   = "KRW-BCH"
+	KRW_BORA  = "KRW-BORA"
+	KRW_BSV   = "KRW-BSV"
+	KRW_BTC   = "KRW-BTC"
+	KRW_BTG   = "KRW-BTG"
+	KRW_BTT   = "KRW-BTT"
+	KRW_CHZ   = "KRW-CHZ"
+	KRW_CRE   = "KRW-CRE"
+	KRW_CRO   = "KRW-CRO"
+	KRW_CVC   = "KRW-CVC"
+	KRW_DCR   = "KRW-DCR"
+	KRW_DKA   = "KRW-DKA"
+	KRW_DMT   = "KRW-DMT"
+	KRW_EDR   = "KRW-EDR"
+	KRW_ELF   = "KRW-ELF"
+	KRW_EMC2  = "KRW-EMC2"
+	KRW_ENJ   = "KRW-ENJ"
 	KRW_EOS   = "KRW-EOS"
-	BTC_DMT   = "BTC-DMT"
-	USDT_TUSD = "USDT-TUSD"
-	KRW_TRX   = "KRW-TRX"
-	BTC_POLY  = "BTC-POLY"
-	KRW_MCO   = "KRW-MCO"
-	BTC_PRO   = "BTC-PRO"
-	USDT_SC   = "USDT-SC"
-	USDT_TRX  = "USDT-TRX"
-	KRW_SC    = "KRW-SC"
+	KRW_ETC   = "KRW-ETC"
+	KRW_ETH   = "KRW-ETH"
+	KRW_GAS   = "KRW-GAS"
+	// Deprecated: KRW-GNT is no longer listed.
+	KRW_GNT   = "KRW-GNT"
+	KRW_GRS   = "KRW-GRS"
 	KRW_GTO   = "KRW-GTO"
+	KRW_HBAR  = "KRW-HBAR"
+	KRW_HIVE  = "KRW-HIVE"
+	KRW_ICX   = "KRW-ICX"
 	KRW_IGNIS = "KRW-IGNIS"
-	KRW_ONT   = "KRW-ONT"
-	KRW_DCR   = "KRW-DCR"
-	KRW_ZIL   = "KRW-ZIL"
-	BTC_GTO   = "BTC-GTO"
-	USDT_DCR  = "USDT-DCR"
-	KRW_POLY  = "KRW-POLY"
-	KRW_ZRX   = "KRW-ZRX"
-	KRW_SRN   = "KRW-SRN"
-	KRW_LOOM  = "KRW-LOOM"
-	BTC_CMCT  = "BTC-CMCT"
-	BTC_BCH   = "BTC-BCH"
-	USDT_BCH  = "USDT-BCH"
-	KRW_BCH   = "KRW-BCH"
-	BTC_MFT   = "BTC-MFT"
-	BTC_LOOM  = "BTC-LOOM"
-	KRW_ADX   = "KRW-ADX"
-	KRW_BAT   = "KRW-BAT"
 	KRW_IOST  = "KRW-IOST"
-	BTC_RFR   = "BTC-RFR"
-	KRW_DMT   = "KRW-DMT"
-	KRW_RFR   = "KRW-RFR"
-	USDT_DGB  = "USDT-DGB"
-	KRW_CVC   = "KRW-CVC"
-	KRW_IQ    = "KRW-IQ"
 	KRW_IOTA  = "KRW-IOTA"
-	KRW_OST   = "KRW-OST"
-	BTC_RVN   = "BTC-RVN"
-	BTC_BFT   = "BTC-BFT"
-	BTC_GO    = "BTC-GO"
-	BTC_UPP   = "BTC-UPP"
-	BTC_ENJ   = "BTC-ENJ"
-	KRW_MFT   = "KRW-MFT"
-	BTC_CRW   = "BTC-CRW"
-	BTC_DTA   = "BTC-DTA"
-	BTC_EDR   = "BTC-EDR"
-	KRW_ONG   = "KRW-ONG"
-	KRW_GAS   = "KRW-GAS"
-	BTC_MTL   = "BTC-MTL"
-	KRW_UPP   = "KRW-UPP"
-	KRW_ELF   = "KRW-ELF"
-	BTC_PMA   = "BTC-PMA"
-	USDT_DOGE = "USDT-DOGE"
-	USDT_ZRX  = "USDT-ZRX"
-	USDT_RVN  = "USDT-RVN"
-	USDT_BAT  = "USDT-BAT"
+	KRW_IQ    = "KRW-IQ"
+	KRW_JST   = "KRW-JST"
+	KRW_KAVA  = "KRW-KAVA"
+	KRW_KMD   = "KRW-KMD"
 	KRW_KNC   = "KRW-KNC"
-	BTC_PAX   = "BTC-PAX"
-	BTC_MOC   = "BTC-MOC"
-	BTC_NPXS  = "BTC-NPXS"
-	BTC_ZIL   = "BTC-ZIL"
-	BTC_OST   = "BTC-OST"
-	BTC_SPC   = "BTC-SPC"
-	KRW_BSV   = "KRW-BSV"
-	BTC_BSV   = "BTC-BSV"
-	BTC_IOST  = "BTC-IOST"
-	BTC_XNK   = "BTC-XNK"
-	KRW_THETA = "KRW-THETA"
-	BTC_NCASH = "BTC-NCASH"
-	BTC_JNT   = "BTC-JNT"
-	BTC_LBA   = "BTC-LBA"
-	KRW_EDR   = "KRW-EDR"
-	BTC_DENT  = "BTC-DENT"
-	KRW_QKC   = "KRW-QKC"
-	BTC_BTM   = "BTC-BTM"
-	BTC_ELF   = "BTC-ELF"
-	KRW_BTT   = "KRW-BTT"
-	BTC_BTT   = "BTC-BTT"
-	BTC_VITE  = "BTC-VITE"
-	BTC_IOTX  = "BTC-IOTX"
-	BTC_BTU   = "BTC-BTU"
-	BTC_SOLVE = "BTC-SOLVE"
-	BTC_NKN   = "BTC-NKN"
-	BTC_QNT   = "BTC-QNT"
-	BTC_CTXC  = "BTC-CTXC"
-	BTC_SPND  = "BTC-SPND"
-	BTC_META  = "BTC-META"
-	KRW_MOC   = "KRW-MOC"
-	BTC_ANKR  = "BTC-ANKR"
-	BTC_CRO   = "BTC-CRO"
-	KRW_ENJ   = "KRW-ENJ"
-	KRW_TFUEL = "KRW-TFUEL"
-	BTC_BTS   = "BTC-BTS"
-	BTC_FSN   = "BTC-FSN"
+	KRW_LINK  = "KRW-LINK"
+	KRW_LOOM  = "KRW-LOOM"
+	KRW_LSK   = "KRW-LSK"
+	KRW_LTC   = "KRW-LTC"
 	KRW_MANA  = "KRW-MANA"
-	BTC_TTC   = "BTC-TTC"
-	KRW_ANKR  = "KRW-ANKR"
-	BTC_ORBS  = "BTC-ORBS"
-	KRW_NPXS  = "KRW-NPXS"
-	BTC_AERGO = "BTC-AERGO"
-	KRW_TTC   = "KRW-TTC"
-	BTC_PI    = "BTC-PI"
-	KRW_AERGO = "KRW-AERGO"
-	KRW_ATOM  = "KRW-ATOM"
-	KRW_TT    = "KRW-TT"
-	KRW_CRE   = "KRW-CRE"
-	KRW_CRO   = "KRW-CRO"
-	BTC_VDX   = "BTC-VDX"
-	KRW_SOLVE = "KRW-SOLVE"
-	BTC_ATOM  = "BTC-ATOM"
-	BTC_STPT  = "BTC-STPT"
 	KRW_MBL   = "KRW-MBL"
-	BTC_LAMB  = "BTC-LAMB"
-	BTC_EOS   = "BTC-EOS"
-	BTC_LUNA  = "BTC-LUNA"
-	BTC_DAI   = "BTC-DAI"
-	BTC_MKR   = "BTC-MKR"
-	BTC_BORA  = "BTC-BORA"
-	KRW_TSHP  = "KRW-TSHP"
-	BTC_TSHP  = "BTC-TSHP"
-	KRW_WAXP  = "KRW-WAXP"
-	BTC_WAXP  = "BTC-WAXP"
-	KRW_HBAR  = "KRW-HBAR"
+	KRW_MCO   = "KRW-MCO"
 	KRW_MED   = "KRW-MED"
-	BTC_MED   = "BTC-MED"
-	BTC_MLK   = "BTC-MLK"
+	KRW_MFT   = "KRW-MFT"
 	KRW_MLK   = "KRW-MLK"
-	BTC_PXL   = "BTC-PXL"
-	KRW_STPT  = "KRW-STPT"
-	BTC_VET   = "BTC-VET"
+	KRW_MOC   = "KRW-MOC"
+	KRW_MTL   = "KRW-MTL"
+	KRW_NEO   = "KRW-NEO"
+	KRW_NPXS  = "KRW-NPXS"
+	KRW_OMG   = "KRW-OMG"
+	KRW_ONG   = "KRW-ONG"
+	KRW_ONT   = "KRW-ONT"
 	KRW_ORBS  = "KRW-ORBS"
-	BTC_CHZ   = "BTC-CHZ"
-	KRW_VET   = "KRW-VET"
-	BTC_FX    = "BTC-FX"
-	BTC_OGN   = "BTC-OGN"
-	KRW_CHZ   = "KRW-CHZ"
+	KRW_OST   = "KRW-OST"
+	KRW_POLY  = "KRW-POLY"
+	KRW_POWR  = "KRW-POWR"
 	KRW_PXL   = "KRW-PXL"
-	BTC_ITAM  = "BTC-ITAM"
-	BTC_XTZ   = "BTC-XTZ"
-	BTC_HIVE  = "BTC-HIVE"
-	BTC_HBD   = "BTC-HBD"
-	BTC_OBSR  = "BTC-OBSR"
-	BTC_DKA   = "BTC-DKA"
-	KRW_STMX  = "KRW-STMX"
-	BTC_STMX  = "BTC-STMX"
-	BTC_AHT   = "BTC-AHT"
-	BTC_PCI   = "BTC-PCI"
-	BTC_RINGX = "BTC-RINGX"
-	BTC_GOM2  = "BTC-GOM2"
-	KRW_DKA   = "KRW-DKA"
-	BTC_LINK  = "BTC-LINK"
-	KRW_HIVE  = "KRW-HIVE"
-	KRW_KAVA  = "KRW-KAVA"
-	BTC_KAVA  = "BTC-KAVA"
-	KRW_AHT   = "KRW-AHT"
+	KRW_QKC   = "KRW-QKC"
+	KRW_QTUM  = "KRW-QTUM"
+	KRW_REP   = "KRW-REP"
+	KRW_RFR   = "KRW-RFR"
+	KRW_SBD   = "KRW-SBD"
+	KRW_SC    = "KRW-SC"
+	KRW_SNT   = "KRW-SNT"
+	KRW_SOLVE = "KRW-SOLVE"
 	KRW_SPND  = "KRW-SPND"
-	KRW_LINK  = "KRW-LINK"
+	KRW_SRN   = "KRW-SRN"
+	KRW_STEEM = "KRW-STEEM"
+	KRW_STMX  = "KRW-STMX"
+	KRW_STORJ = "KRW-STORJ"
+	KRW_STPT  = "KRW-STPT"
+	// Deprecated: KRW-STRAT is no longer listed.
+	KRW_STRAT = "KRW-STRAT"
+	KRW_TFUEL = "KRW-TFUEL"
+	KRW_THETA = "KRW-THETA"
+	KRW_TRX   = "KRW-TRX"
+	KRW_TSHP  = "KRW-TSHP"
+	KRW_TT    = "KRW-TT"
+	KRW_TTC   = "KRW-TTC"
+	KRW_UPP   = "KRW-UPP"
+	KRW_VET   = "KRW-VET"
+	KRW_VTC   = "KRW-VTC"
+	KRW_WAVES = "KRW-WAVES"
+	KRW_WAXP  = "KRW-WAXP"
+	KRW_XEM   = "KRW-XEM"
+	KRW_XLM   = "KRW-XLM"
+	KRW_XRP   = "KRW-XRP"
 	KRW_XTZ   = "KRW-XTZ"
-	KRW_BORA  = "KRW-BORA"
-	BTC_JST   = "BTC-JST"
-	KRW_JST   = "KRW-JST"
-	BTC_CHR   = "BTC-CHR"
-	BTC_DAD   = "BTC-DAD"
+	KRW_ZIL   = "KRW-ZIL"
+	KRW_ZRX   = "KRW-ZRX"
+	USDT_ADA  = "USDT-ADA"
+	USDT_BAT  = "USDT-BAT"
+	USDT_BCH  = "USDT-BCH"
+	USDT_BTC  = "USDT-BTC"
+	USDT_DCR  = "USDT-DCR"
+	USDT_DGB  = "USDT-DGB"
+	USDT_DOGE = "USDT-DOGE"
+	USDT_ETC  = "USDT-ETC"
+	USDT_ETH  = "USDT-ETH"
+	USDT_LTC  = "USDT-LTC"
+	USDT_OMG  = "USDT-OMG"
+	USDT_RVN  = "USDT-RVN"
+	USDT_SC   = "USDT-SC"
+	USDT_TRX  = "USDT-TRX"
+	USDT_TUSD = "USDT-TUSD"
+	USDT_XRP  = "USDT-XRP"
+	USDT_ZRX  = "USDT-ZRX"
 )
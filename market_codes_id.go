@@ -0,0 +1,11 @@
+// Code generated by go run ./cmd/marketcodes; DO NOT EDIT.
+
+package upbit
+
+// Markets listed on Upbit ID when this file was generated. Use
+// MarketRegistry to follow listings at run time.
+const (
+	ID_IDR_BTC = "IDR-BTC"
+	ID_IDR_ETH = "IDR-ETH"
+	ID_IDR_XRP = "IDR-XRP"
+)
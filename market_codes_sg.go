@@ -0,0 +1,11 @@
+// Code generated by go run ./cmd/marketcodes; DO NOT EDIT.
+
+package upbit
+
+// Markets listed on Upbit SG when this file was generated. Use
+// MarketRegistry to follow listings at run time.
+const (
+	SG_SGD_BTC = "SGD-BTC"
+	SG_SGD_ETH = "SGD-ETH"
+	SG_SGD_XRP = "SGD-XRP"
+)
@@ -0,0 +1,198 @@
+package upbit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	MarketWarningNone    = "NONE"
+	MarketWarningCaution = "CAUTION" // 투자유의
+)
+
+// ParseMarket splits a market code such as "KRW-BTC" into its quote ("KRW")
+// and base ("BTC") currencies.
+func ParseMarket(market string) (quote, base string, err error) {
+	i := strings.Index(market, "-")
+	if i <= 0 || i == len(market)-1 {
+		return "", "", fmt.Errorf("upbit: invalid market %q", market)
+	}
+	return market[:i], market[i+1:], nil
+}
+
+func (m *MarketCode) Quote() string {
+	quote, _, _ := ParseMarket(m.Market)
+	return quote
+}
+
+func (m *MarketCode) Base() string {
+	_, base, _ := ParseMarket(m.Market)
+	return base
+}
+
+func (m *MarketCode) Caution() bool {
+	return m.MarketWarning == MarketWarningCaution
+}
+
+// MarketDiff is the change between two refreshes of a MarketRegistry.
+type MarketDiff struct {
+	Listed   []*MarketCode
+	Delisted []*MarketCode
+	// Warned and Unwarned are the markets that entered and left the
+	// CAUTION state.
+	Warned   []*MarketCode
+	Unwarned []*MarketCode
+}
+
+func (d *MarketDiff) Empty() bool {
+	return len(d.Listed) == 0 && len(d.Delisted) == 0 && len(d.Warned) == 0 && len(d.Unwarned) == 0
+}
+
+// MarketRegistry caches the markets listed on Upbit. Call Refresh to load
+// them, and periodically to follow listings and delistings. MarketRegistry
+// is safe for concurrent use.
+type MarketRegistry struct {
+	markets *MarketService
+
+	mu          sync.RWMutex
+	byCode      map[string]*MarketCode
+	byKorean    map[string][]*MarketCode
+	byEnglish   map[string][]*MarketCode
+	refreshedAt time.Time
+}
+
+func NewMarketRegistry(markets *MarketService) *MarketRegistry {
+	return &MarketRegistry{
+		markets: markets,
+		byCode:  map[string]*MarketCode{},
+	}
+}
+
+// Refresh reloads the markets and reports what changed since the last
+// refresh. Everything is reported as listed on the first refresh.
+func (r *MarketRegistry) Refresh(ctx context.Context) (*MarketDiff, error) {
+	markets, _, err := r.markets.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byCode := make(map[string]*MarketCode, len(markets))
+	byKorean := make(map[string][]*MarketCode)
+	byEnglish := make(map[string][]*MarketCode)
+	for _, m := range markets {
+		byCode[m.Market] = m
+		byKorean[m.KoreanName] = append(byKorean[m.KoreanName], m)
+		english := strings.ToLower(m.EnglishName)
+		byEnglish[english] = append(byEnglish[english], m)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	diff := &MarketDiff{}
+	for code, m := range byCode {
+		old, ok := r.byCode[code]
+		switch {
+		case !ok:
+			diff.Listed = append(diff.Listed, m)
+		case m.Caution() && !old.Caution():
+			diff.Warned = append(diff.Warned, m)
+		case !m.Caution() && old.Caution():
+			diff.Unwarned = append(diff.Unwarned, m)
+		}
+	}
+	for code, old := range r.byCode {
+		if _, ok := byCode[code]; !ok {
+			diff.Delisted = append(diff.Delisted, old)
+		}
+	}
+	sortMarkets(diff.Listed)
+	sortMarkets(diff.Delisted)
+	sortMarkets(diff.Warned)
+	sortMarkets(diff.Unwarned)
+
+	r.byCode = byCode
+	r.byKorean = byKorean
+	r.byEnglish = byEnglish
+	r.refreshedAt = time.Now()
+	return diff, nil
+}
+
+// RefreshedAt returns the time of the last successful Refresh.
+func (r *MarketRegistry) RefreshedAt() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.refreshedAt
+}
+
+func (r *MarketRegistry) Market(code string) (*MarketCode, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	m, ok := r.byCode[code]
+	return m, ok
+}
+
+// Markets returns all markets sorted by code.
+func (r *MarketRegistry) Markets() []*MarketCode {
+	return r.filter(func(*MarketCode) bool { return true })
+}
+
+// ByQuote returns the markets quoted in currency, e.g. "KRW".
+func (r *MarketRegistry) ByQuote(currency string) []*MarketCode {
+	return r.filter(func(m *MarketCode) bool { return m.Quote() == currency })
+}
+
+// ByBase returns the markets trading currency, e.g. "BTC" gives KRW-BTC
+// and USDT-BTC.
+func (r *MarketRegistry) ByBase(currency string) []*MarketCode {
+	return r.filter(func(m *MarketCode) bool { return m.Base() == currency })
+}
+
+// Cautioned returns the markets under CAUTION (투자유의).
+func (r *MarketRegistry) Cautioned() []*MarketCode {
+	return r.filter((*MarketCode).Caution)
+}
+
+// ByKoreanName returns the markets of the currency named name in Korean,
+// one per quote currency.
+func (r *MarketRegistry) ByKoreanName(name string) []*MarketCode {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return copyMarkets(r.byKorean[name])
+}
+
+// ByEnglishName is ByKoreanName for English names, ignoring case.
+func (r *MarketRegistry) ByEnglishName(name string) []*MarketCode {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return copyMarkets(r.byEnglish[strings.ToLower(name)])
+}
+
+func (r *MarketRegistry) filter(keep func(*MarketCode) bool) []*MarketCode {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var markets []*MarketCode
+	for _, m := range r.byCode {
+		if keep(m) {
+			markets = append(markets, m)
+		}
+	}
+	sortMarkets(markets)
+	return markets
+}
+
+func copyMarkets(markets []*MarketCode) []*MarketCode {
+	markets = append([]*MarketCode(nil), markets...)
+	sortMarkets(markets)
+	return markets
+}
+
+func sortMarkets(markets []*MarketCode) {
+	sort.Slice(markets, func(i, j int) bool { return markets[i].Market < markets[j].Market })
+}
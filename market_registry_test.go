@@ -0,0 +1,85 @@
+package upbit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+func TestMarketRegistry(t *testing.T) {
+	responses := []string{
+		`[{"market":"KRW-BTC","korean_name":"비트코인","english_name":"Bitcoin","market_warning":"NONE"},
+		  {"market":"BTC-STRAT","korean_name":"스트라티스","english_name":"Stratis","market_warning":"NONE"},
+		  {"market":"KRW-DOGE","korean_name":"도지코인","english_name":"Dogecoin","market_warning":"NONE"}]`,
+		`[{"market":"KRW-BTC","korean_name":"비트코인","english_name":"Bitcoin","market_warning":"NONE"},
+		  {"market":"USDT-BTC","korean_name":"비트코인","english_name":"Bitcoin","market_warning":"NONE"},
+		  {"market":"KRW-DOGE","korean_name":"도지코인","english_name":"Dogecoin","market_warning":"CAUTION"}]`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(responses[0]))
+		responses = responses[1:]
+	}))
+	defer srv.Close()
+
+	client, err := upbit.NewClient(nil, &upbit.ClientOptions{ServerURL: srv.URL, RateLimitPolicy: upbit.RateLimitDisabled})
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := upbit.NewMarketRegistry(client.Markets)
+	ctx := context.Background()
+
+	diff, err := registry.Refresh(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Listed) != 3 || len(diff.Delisted) != 0 {
+		t.Errorf("first refresh: %d listed, %d delisted", len(diff.Listed), len(diff.Delisted))
+	}
+
+	diff, err = registry.Refresh(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if codes := marketCodes(diff.Listed); codes != "USDT-BTC" {
+		t.Errorf("listed = %s", codes)
+	}
+	if codes := marketCodes(diff.Delisted); codes != "BTC-STRAT" {
+		t.Errorf("delisted = %s", codes)
+	}
+	if codes := marketCodes(diff.Warned); codes != "KRW-DOGE" {
+		t.Errorf("warned = %s", codes)
+	}
+
+	if codes := marketCodes(registry.ByQuote("KRW")); codes != "KRW-BTC KRW-DOGE" {
+		t.Errorf("KRW markets = %s", codes)
+	}
+	if codes := marketCodes(registry.ByEnglishName("bitcoin")); codes != "KRW-BTC USDT-BTC" {
+		t.Errorf("bitcoin = %s", codes)
+	}
+	if codes := marketCodes(registry.ByKoreanName("도지코인")); codes != "KRW-DOGE" {
+		t.Errorf("도지코인 = %s", codes)
+	}
+	if codes := marketCodes(registry.Cautioned()); codes != "KRW-DOGE" {
+		t.Errorf("cautioned = %s", codes)
+	}
+	if m, ok := registry.Market("USDT-BTC"); !ok || m.Quote() != "USDT" || m.Base() != "BTC" {
+		t.Errorf("USDT-BTC = %+v", m)
+	}
+	if _, ok := registry.Market("BTC-STRAT"); ok {
+		t.Error("delisted market still registered")
+	}
+}
+
+func marketCodes(markets []*upbit.MarketCode) string {
+	var codes string
+	for i, m := range markets {
+		if i > 0 {
+			codes += " "
+		}
+		codes += m.Market
+	}
+	return codes
+}
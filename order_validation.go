@@ -0,0 +1,200 @@
+package upbit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Reasons of an *OrderValidationError.
+var (
+	ErrMarketNotActive     = fmt.Errorf("upbit: market is not active")
+	ErrUnsupportedSide     = fmt.Errorf("upbit: side is not supported by the market")
+	ErrUnsupportedOrdType  = fmt.Errorf("upbit: ord_type is not supported by the market")
+	ErrBelowMinTotal       = fmt.Errorf("upbit: order total is below the minimum")
+	ErrAboveMaxTotal       = fmt.Errorf("upbit: order total is above the maximum")
+	ErrInsufficientBalance = fmt.Errorf("upbit: insufficient balance")
+)
+
+const defaultChanceTTL = 10 * time.Second
+
+// OrderValidationError is returned when an order is rejected locally against
+// the market's Chance. It matches its reason with errors.Is and, where one
+// exists, the error the server would have answered, so that
+//
+//	errors.Is(err, upbit.ErrUnderMinTotalBid)
+//	upbit.IsInsufficientFunds(err)
+//
+// hold for both local and remote rejections.
+type OrderValidationError struct {
+	Reason  error
+	Market  string
+	Side    string
+	Message string
+}
+
+func (e *OrderValidationError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Reason, e.Message)
+}
+
+func (e *OrderValidationError) Unwrap() error {
+	return e.Reason
+}
+
+func (e *OrderValidationError) Is(target error) bool {
+	bid := e.Side == SideBid
+	switch e.Reason {
+	case ErrBelowMinTotal:
+		return bid && target == ErrUnderMinTotalBid || !bid && target == ErrUnderMinTotalAsk
+	case ErrInsufficientBalance:
+		return bid && target == ErrInsufficientFundsBid || !bid && target == ErrInsufficientFundsAsk
+	}
+	return false
+}
+
+type chanceEntry struct {
+	chance  *Chance
+	fetched time.Time
+}
+
+// chanceCache caches OrderService.Chances per market.
+type chanceCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*chanceEntry
+}
+
+func newChanceCache(ttl time.Duration) *chanceCache {
+	if ttl <= 0 {
+		ttl = defaultChanceTTL
+	}
+	return &chanceCache{
+		ttl:     ttl,
+		entries: map[string]*chanceEntry{},
+	}
+}
+
+func (c *chanceCache) get(ctx context.Context, s *OrderService, market string) (*Chance, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[market]
+	c.mu.Unlock()
+
+	if ok && time.Since(entry.fetched) < c.ttl {
+		return entry.chance, nil
+	}
+
+	chance, _, err := s.Chances(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[market] = &chanceEntry{chance: chance, fetched: time.Now()}
+	c.mu.Unlock()
+
+	return chance, nil
+}
+
+func (c *chanceCache) invalidate(market string) {
+	c.mu.Lock()
+	delete(c.entries, market)
+	c.mu.Unlock()
+}
+
+// CachedChance returns the Chance of market, fetching it if the cached one is
+// older than ClientOptions.ChanceTTL.
+func (s *OrderService) CachedChance(ctx context.Context, market string) (*Chance, error) {
+	return s.client.chances.get(ctx, s, market)
+}
+
+// Validate checks orderReq against the cached Chance of its market and
+// returns an *OrderValidationError if Upbit would reject it.
+func (s *OrderService) Validate(ctx context.Context, orderReq *OrderRequest) error {
+	if orderReq == nil {
+		return ErrInvalidArguments
+	}
+
+	chance, err := s.CachedChance(ctx, orderReq.Market)
+	if err != nil {
+		return err
+	}
+
+	return ValidateOrder(chance, orderReq)
+}
+
+// ValidateOrder checks orderReq against chance: market state, side, ord_type,
+// minimum and maximum total and available balance including the fee. It
+// returns a plain error if a decimal of either is not a number.
+func ValidateOrder(chance *Chance, orderReq *OrderRequest) error {
+	if err := checkDecimals(orderReq.Price, orderReq.Volume,
+		chance.BidFee, chance.AskFee, chance.Market.MaxTotal,
+		chance.Market.Bid.MinTotal, chance.Market.Ask.MinTotal,
+		chance.BidAccount.Balance, chance.AskAccount.Balance); err != nil {
+		return err
+	}
+
+	reject := func(reason error, format string, args ...interface{}) error {
+		return &OrderValidationError{
+			Reason:  reason,
+			Market:  orderReq.Market,
+			Side:    orderReq.Side,
+			Message: fmt.Sprintf(format, args...),
+		}
+	}
+
+	market := chance.Market
+	if market.State != "" && market.State != MarketStateActive {
+		return reject(ErrMarketNotActive, "%s is %s", orderReq.Market, market.State)
+	}
+	if len(market.OrderSides) > 0 && !containsString(market.OrderSides, orderReq.Side) {
+		return reject(ErrUnsupportedSide, "%s accepts %v", orderReq.Market, market.OrderSides)
+	}
+
+	ordTypes := market.OrderTypes
+	if orderReq.Side == SideBid && len(market.BidTypes) > 0 {
+		ordTypes = market.BidTypes
+	} else if orderReq.Side == SideAsk && len(market.AskTypes) > 0 {
+		ordTypes = market.AskTypes
+	}
+	if len(ordTypes) > 0 && !containsString(ordTypes, orderReq.OrdType) {
+		return reject(ErrUnsupportedOrdType, "%s %s accepts %v", orderReq.Market, orderReq.Side, ordTypes)
+	}
+
+	// The total of a market ask is unknown until it is filled.
+	var total Decimal
+	switch orderReq.OrdType {
+	case OrdTypeLimit:
+		total = orderReq.Price.Mul(orderReq.Volume)
+	case OrdTypePrice:
+		total = orderReq.Price
+	}
+
+	minTotal, fee, account := market.Bid.MinTotal, chance.BidFee, chance.BidAccount
+	if orderReq.Side == SideAsk {
+		minTotal, fee, account = market.Ask.MinTotal, chance.AskFee, chance.AskAccount
+	}
+
+	if total != "" {
+		if total.LessThan(minTotal) {
+			return reject(ErrBelowMinTotal, "total %s is below %s", total, minTotal)
+		}
+		if market.MaxTotal.Sign() > 0 && total.GreaterThan(market.MaxTotal) {
+			return reject(ErrAboveMaxTotal, "total %s is above %s", total, market.MaxTotal)
+		}
+	}
+
+	if orderReq.Side == SideBid {
+		required := total.Add(total.Mul(fee))
+		if total != "" && account.Balance.LessThan(required) {
+			return reject(ErrInsufficientBalance, "%s %s required including fee, %s available",
+				required, account.Currency, account.Balance)
+		}
+	} else if orderReq.Volume != "" && account.Balance.LessThan(orderReq.Volume) {
+		return reject(ErrInsufficientBalance, "%s %s required, %s available",
+			orderReq.Volume, account.Currency, account.Balance)
+	}
+
+	return nil
+}
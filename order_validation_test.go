@@ -0,0 +1,125 @@
+package upbit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+const testChance = `{
+	"bid_fee": "0.0005",
+	"ask_fee": "0.0005",
+	"market": {
+		"id": "KRW-BTC",
+		"order_types": ["limit"],
+		"bid_types": ["limit", "price"],
+		"ask_types": ["limit", "market"],
+		"order_sides": ["ask", "bid"],
+		"bid": {"currency": "KRW", "min_total": "5000"},
+		"ask": {"currency": "BTC", "min_total": "5000"},
+		"max_total": "1000000000",
+		"state": "active"
+	},
+	"bid_account": {"currency": "KRW", "balance": "10000"},
+	"ask_account": {"currency": "BTC", "balance": "0.001"}
+}`
+
+func TestValidateOrder(t *testing.T) {
+	var chances, orders int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/orders/chance":
+			atomic.AddInt32(&chances, 1)
+			w.Write([]byte(testChance))
+		case "/v1/orders":
+			atomic.AddInt32(&orders, 1)
+			w.Write([]byte(`{"uuid":"1"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := upbit.NewClient(nil, &upbit.ClientOptions{
+		AccessKey:       "access",
+		SecretKey:       "secret",
+		ServerURL:       srv.URL,
+		RateLimitPolicy: upbit.RateLimitDisabled,
+		ValidateOrders:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name   string
+		req    upbit.OrderRequest
+		reason error
+	}{
+		{"valid", upbit.OrderRequest{Side: upbit.SideBid, OrdType: upbit.OrdTypeLimit, Price: "5000000", Volume: "0.001"}, nil},
+		{"below min total", upbit.OrderRequest{Side: upbit.SideBid, OrdType: upbit.OrdTypeLimit, Price: "1000", Volume: "1"}, upbit.ErrBelowMinTotal},
+		{"fee exceeds balance", upbit.OrderRequest{Side: upbit.SideBid, OrdType: upbit.OrdTypePrice, Price: "10000"}, upbit.ErrInsufficientBalance},
+		{"ask volume", upbit.OrderRequest{Side: upbit.SideAsk, OrdType: upbit.OrdTypeMarket, Volume: "0.01"}, upbit.ErrInsufficientBalance},
+		{"unsupported ord_type", upbit.OrderRequest{Side: upbit.SideBid, OrdType: upbit.OrdTypeMarket, Volume: "0.001"}, upbit.ErrUnsupportedOrdType},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.req.Market = "KRW-BTC"
+			err := client.Orders.Validate(ctx, &tc.req)
+			if !errors.Is(err, tc.reason) || tc.reason == nil && err != nil {
+				t.Fatalf("err = %v, want %v", err, tc.reason)
+			}
+		})
+	}
+	if chances != 1 {
+		t.Errorf("chance fetched %d times, want 1", chances)
+	}
+
+	_, _, err = client.Orders.Order(ctx, &upbit.OrderRequest{
+		Market: "KRW-BTC", Side: upbit.SideBid, OrdType: upbit.OrdTypeLimit, Price: "1000", Volume: "1",
+	})
+	if !errors.Is(err, upbit.ErrUnderMinTotalBid) {
+		t.Errorf("err = %v, want %v", err, upbit.ErrUnderMinTotalBid)
+	}
+	if orders != 0 {
+		t.Errorf("%d orders posted, want 0", orders)
+	}
+
+	if _, _, err := client.Orders.Order(ctx, &upbit.OrderRequest{
+		Market: "KRW-BTC", Side: upbit.SideBid, OrdType: upbit.OrdTypeLimit, Price: "5000000", Volume: "0.001",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Orders.CachedChance(ctx, "KRW-BTC"); err != nil {
+		t.Fatal(err)
+	}
+	if orders != 1 || chances != 2 {
+		t.Errorf("orders = %d, chances = %d, want 1 and 2", orders, chances)
+	}
+}
+
+func TestValidateOrderInactive(t *testing.T) {
+	chance := &upbit.Chance{}
+	chance.Market.State = "delisted"
+
+	err := upbit.ValidateOrder(chance, &upbit.OrderRequest{Market: "KRW-BTC", Side: upbit.SideBid})
+	var verr *upbit.OrderValidationError
+	if !errors.As(err, &verr) || verr.Reason != upbit.ErrMarketNotActive {
+		t.Errorf("err = %v, want %v", err, upbit.ErrMarketNotActive)
+	}
+}
+
+func TestValidateOrderInvalidDecimal(t *testing.T) {
+	err := upbit.ValidateOrder(&upbit.Chance{}, &upbit.OrderRequest{
+		Market: "KRW-BTC", Side: upbit.SideBid, OrdType: upbit.OrdTypeLimit, Price: "1,000", Volume: "1",
+	})
+	var verr *upbit.OrderValidationError
+	if err == nil || errors.As(err, &verr) {
+		t.Errorf("err = %v, want a plain error", err)
+	}
+}
@@ -2,6 +2,7 @@ package upbit
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -121,7 +122,94 @@ func (s *OrderService) ListOrders(ctx context.Context, listOpt *OrderListOptions
 	return orders, resp, nil
 }
 
+// Order places an order. With a RetryPolicy set, an order with an Identifier
+// is retried after a failure; before posting again Order looks the
+// identifier up, so an order that reached Upbit despite a timeout or error
+// response is returned instead of being placed twice. Orders without an
+// Identifier are never retried.
+//
+// The price of a limit order is checked against the price unit according to
+// ClientOptions.PriceUnitPolicy. With ClientOptions.ValidateOrders the order
+// is also checked by ValidateOrder against the cached Chance of the market,
+// whose price unit is then preferred over the price unit table of the
+// client's Region.
 func (s *OrderService) Order(ctx context.Context, orderReq *OrderRequest) (*Order, *http.Response, error) {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
+	if orderReq == nil {
+		return nil, nil, ErrInvalidArguments
+	}
+	if _, err := NewDecimal(string(orderReq.Price)); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidArguments, err)
+	}
+	if _, err := NewDecimal(string(orderReq.Volume)); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidArguments, err)
+	}
+
+	var chance *Chance
+	var unit Decimal
+	if orderReq.OrdType == OrdTypeLimit && orderReq.Price != "" {
+		unit = s.client.region.PriceUnit(orderReq.Market, orderReq.Price)
+	}
+	if s.client.validate {
+		var err error
+		chance, err = s.CachedChance(ctx, orderReq.Market)
+		if err != nil {
+			return nil, nil, err
+		}
+		if reported := chance.reportedPriceUnit(orderReq.Side); reported.Sign() > 0 {
+			unit = reported
+		}
+	}
+
+	orderReq, err := normalizePrice(orderReq, unit, s.client.priceUnit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if chance != nil {
+		if err := ValidateOrder(chance, orderReq); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	order, resp, err := s.submit(ctx, orderReq)
+	if err == nil {
+		// Balances of the cached chance are stale now.
+		s.client.chances.invalidate(orderReq.Market)
+	}
+	return order, resp, err
+}
+
+func (s *OrderService) submit(ctx context.Context, orderReq *OrderRequest) (*Order, *http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		order, resp, err := s.order(ctx, orderReq)
+		if err == nil || s.client.retryPolicy == nil || orderReq.Identifier == "" {
+			return order, resp, err
+		}
+
+		wait, ok := s.client.retryPolicy.Retry(attempt, resp, err)
+		if !ok {
+			return order, resp, err
+		}
+		if serr := sleepContext(ctx, wait); serr != nil {
+			return order, resp, err
+		}
+
+		placed, presp, perr := s.GetOrderByIdentifier(ctx, orderReq.Identifier)
+		if perr == nil {
+			return placed, presp, nil
+		}
+		if !errors.Is(perr, ErrOrderNotFound) {
+			// Whether the order was placed is unknown; don't risk a duplicate.
+			return order, resp, err
+		}
+	}
+}
+
+func (s *OrderService) order(ctx context.Context, orderReq *OrderRequest) (*Order, *http.Response, error) {
 	qv, err := query.Values(orderReq)
 	if err != nil {
 		return nil, nil, err
@@ -174,6 +262,7 @@ func (s *OrderService) Chances(ctx context.Context, market string) (*Chance, *ht
 	if err != nil {
 		return nil, resp, err
 	}
+	chance.region = s.client.region
 
-	return chance, nil, nil
+	return chance, resp, nil
 }
@@ -0,0 +1,428 @@
+package upbit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/investing-kr/go-upbit/internal/ledger"
+)
+
+// PaperFill decides what a PaperTrader fills orders against.
+type PaperFill int
+
+const (
+	// PaperFillOrderbook fills orders against the visible levels of the live
+	// orderbook at their prices, like a taker.
+	PaperFillOrderbook PaperFill = iota
+	// PaperFillTicker fills new orders in full at the last trade price,
+	// like a taker. Limit orders left waiting fill at their limit price once
+	// a later trade crosses it.
+	PaperFillTicker
+)
+
+type PaperTraderOptions struct {
+	// Balances are the initial available balances by currency, used when
+	// there is no saved state.
+	Balances map[string]Decimal
+
+	// StatePath is a JSON file the balances and orders are saved to after
+	// every change and loaded from by NewPaperTrader. Empty keeps the state
+	// in memory only.
+	StatePath string
+
+	Fill PaperFill
+
+	// Fee is the fee rate of both sides. Empty takes the fee rates of the
+	// market's Chance, which needs the client's API keys.
+	Fee Decimal
+}
+
+// PaperTrader is a Trader that simulates fills against live quotations of
+// the client and keeps virtual balances. Orders lock funds like Upbit does,
+// limit orders that do not fill wait and are matched again whenever orders
+// or accounts are read or Update is called, and the rest of market orders is
+// cancelled. The client's PriceUnitPolicy and ValidateOrders apply.
+//
+// Responses are always nil. PaperTrader is safe for concurrent use.
+type PaperTrader struct {
+	client *Client
+	opts   PaperTraderOptions
+
+	mu     sync.Mutex
+	ledger *ledger.Ledger
+}
+
+// NewPaperTrader returns a PaperTrader quoting through client. The state
+// saved at opts.StatePath is restored if the file exists.
+func NewPaperTrader(client *Client, opts *PaperTraderOptions) (*PaperTrader, error) {
+	p := &PaperTrader{
+		client: client,
+		ledger: ledger.New("KRW"),
+	}
+	if opts != nil {
+		p.opts = *opts
+	}
+
+	if p.opts.StatePath != "" {
+		b, err := ioutil.ReadFile(p.opts.StatePath)
+		if err == nil {
+			if err := json.Unmarshal(b, p.ledger); err != nil {
+				return nil, err
+			}
+			return p, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	for currency, balance := range p.opts.Balances {
+		p.ledger.Account(currency).Balance = string(balance)
+	}
+	return p, p.save()
+}
+
+func (p *PaperTrader) Order(ctx context.Context, orderReq *OrderRequest) (*Order, *http.Response, error) {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	if orderReq == nil || (orderReq.Side != SideBid && orderReq.Side != SideAsk) {
+		return nil, nil, ErrInvalidArguments
+	}
+	for _, d := range []Decimal{orderReq.Price, orderReq.Volume} {
+		if v, err := NewDecimal(string(d)); err != nil || v.Sign() < 0 {
+			return nil, nil, ErrInvalidArguments
+		}
+	}
+
+	switch {
+	case orderReq.OrdType == OrdTypeLimit && orderReq.Price != "" && orderReq.Volume != "":
+	case orderReq.OrdType == OrdTypePrice && orderReq.Side == SideBid && orderReq.Price != "" && orderReq.Volume == "":
+	case orderReq.OrdType == OrdTypeMarket && orderReq.Side == SideAsk && orderReq.Volume != "" && orderReq.Price == "":
+	default:
+		return nil, nil, ErrInvalidArguments
+	}
+
+	orderReq, err := normalizePrice(orderReq, p.client.region.PriceUnit(orderReq.Market, orderReq.Price), p.client.priceUnit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if p.client.validate {
+		chance, _, err := p.Chances(ctx, orderReq.Market)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := ValidateOrder(chance, orderReq); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	fee, err := p.fee(ctx, orderReq.Market, orderReq.Side)
+	if err != nil {
+		return nil, nil, err
+	}
+	levels, err := p.levels(ctx, orderReq.Market, orderReq.Side)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, _, err := ParseMarket(orderReq.Market); err != nil {
+		return nil, nil, ErrInvalidArguments
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if orderReq.Identifier != "" && p.ledger.Find("", orderReq.Identifier) != nil {
+		return nil, nil, ErrInvalidArguments
+	}
+
+	o, err := p.ledger.Place(ledgerRequest(orderReq, fee), time.Now())
+	if err != nil {
+		return nil, nil, ledgerError(orderReq, err)
+	}
+	p.ledger.Match(o, levels, false)
+
+	if err := p.save(); err != nil {
+		return nil, nil, err
+	}
+	return ledgerOrder(o), nil, nil
+}
+
+func (p *PaperTrader) GetOrderByUUID(ctx context.Context, uuid string) (*Order, *http.Response, error) {
+	if err := p.Update(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	o := p.ledger.Find(uuid, "")
+	if o == nil {
+		return nil, nil, ErrOrderNotFound
+	}
+	return ledgerOrder(o), nil, nil
+}
+
+func (p *PaperTrader) CancelOrderByUUID(ctx context.Context, uuid string) (*Order, *http.Response, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	o := p.ledger.Find(uuid, "")
+	if o == nil {
+		return nil, nil, ErrOrderNotFound
+	}
+	if o.State != OrderStateWait {
+		return nil, nil, ErrInvalidArguments
+	}
+
+	p.ledger.Cancel(o)
+	if err := p.save(); err != nil {
+		return nil, nil, err
+	}
+	return ledgerOrder(o), nil, nil
+}
+
+// ListOrders filters the orders like Upbit: by market, by state, waiting by
+// default, or by UUIDs or identifiers, newest first unless OrderBy is "asc".
+func (p *PaperTrader) ListOrders(ctx context.Context, listOpt *OrderListOptions) ([]*Order, *http.Response, error) {
+	if err := p.Update(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	listed := p.ledger.List(ledgerQuery(listOpt))
+	orders := make([]*Order, len(listed))
+	for i, o := range listed {
+		orders[i] = ledgerOrder(o)
+	}
+	return orders, nil, nil
+}
+
+// Chances returns the Chance of market from the client, with the virtual
+// accounts in place of the real ones.
+func (p *PaperTrader) Chances(ctx context.Context, market string) (*Chance, *http.Response, error) {
+	cached, err := p.client.Orders.CachedChance(ctx, market)
+	if err != nil {
+		return nil, nil, err
+	}
+	quote, base, err := ParseMarket(market)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	chance := *cached
+	chance.BidAccount = *ledgerAccount(p.ledger.Account(quote))
+	chance.AskAccount = *ledgerAccount(p.ledger.Account(base))
+	if p.opts.Fee != "" {
+		chance.BidFee, chance.AskFee = p.opts.Fee, p.opts.Fee
+	}
+	return &chance, nil, nil
+}
+
+// Accounts returns the virtual accounts with a balance.
+func (p *PaperTrader) Accounts(ctx context.Context) ([]*Account, *http.Response, error) {
+	if err := p.Update(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	accounts := []*Account{}
+	for _, a := range p.ledger.Holdings() {
+		accounts = append(accounts, ledgerAccount(a))
+	}
+	return accounts, nil, nil
+}
+
+// Update matches the waiting orders against the current quotations.
+func (p *PaperTrader) Update(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
+	type key struct{ market, side string }
+	p.mu.Lock()
+	waiting := map[key]bool{}
+	for _, o := range p.ledger.Orders {
+		if o.State == OrderStateWait {
+			waiting[key{o.Market, o.Side}] = true
+		}
+	}
+	p.mu.Unlock()
+
+	for k := range waiting {
+		levels, err := p.levels(ctx, k.market, k.side)
+		if err != nil {
+			return err
+		}
+
+		p.mu.Lock()
+		for _, o := range p.ledger.Orders {
+			if o.Market == k.market && o.Side == k.side && o.State == OrderStateWait {
+				// Waiting orders are makers: a trade through their price fills
+				// them at that price.
+				levels = p.ledger.Match(o, levels, p.opts.Fill == PaperFillTicker)
+			}
+		}
+		err = p.save()
+		p.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PaperTrader) fee(ctx context.Context, market, side string) (Decimal, error) {
+	if p.opts.Fee != "" {
+		return p.opts.Fee, nil
+	}
+	chance, err := p.client.Orders.CachedChance(ctx, market)
+	if err != nil {
+		return "", err
+	}
+	if side == SideAsk {
+		return chance.AskFee, nil
+	}
+	return chance.BidFee, nil
+}
+
+// levels returns the liquidity an order of side in market can take, best
+// first. With PaperFillTicker it is a single level of unlimited size at the
+// last trade price, marked by an empty Size.
+func (p *PaperTrader) levels(ctx context.Context, market, side string) ([]ledger.Level, error) {
+	if p.opts.Fill == PaperFillTicker {
+		ticker, _, err := p.client.Candles.TickerMarket(ctx, market)
+		if err != nil {
+			return nil, err
+		}
+		return []ledger.Level{{Price: string(ticker.TradePrice)}}, nil
+	}
+
+	ob, _, err := p.client.Quotations.OrderbookMarket(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+	book, err := NewBook(ob)
+	if err != nil {
+		return nil, err
+	}
+	return ledgerLevels(book.levels(side)), nil
+}
+
+// save writes the state to StatePath through a temporary file, so that a
+// crash leaves either the old or the new state.
+func (p *PaperTrader) save() error {
+	if p.opts.StatePath == "" {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(p.ledger, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := p.opts.StatePath + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p.opts.StatePath)
+}
+
+// The ledger's decimals are the strings of Decimal. package ledger cannot
+// import upbit, so the conversions below are repeated for the other packages
+// built on it in internal/ledger/ledgerconv.
+
+func ledgerRequest(req *OrderRequest, fee Decimal) *ledger.Request {
+	return &ledger.Request{
+		Market:     req.Market,
+		Side:       req.Side,
+		OrdType:    req.OrdType,
+		Price:      string(req.Price),
+		Volume:     string(req.Volume),
+		Identifier: req.Identifier,
+		Fee:        string(fee),
+	}
+}
+
+// ledgerError returns err of placing req, an *OrderValidationError for a
+// short balance.
+func ledgerError(req *OrderRequest, err error) error {
+	var short *ledger.InsufficientError
+	if !errors.As(err, &short) {
+		return err
+	}
+	return &OrderValidationError{
+		Reason:  ErrInsufficientBalance,
+		Market:  req.Market,
+		Side:    req.Side,
+		Message: short.Error(),
+	}
+}
+
+func ledgerQuery(opt *OrderListOptions) *ledger.Query {
+	if opt == nil {
+		return &ledger.Query{}
+	}
+	return &ledger.Query{
+		Market:      opt.Market,
+		State:       opt.State,
+		States:      opt.States,
+		UUIDs:       opt.UUIDs,
+		Identifiers: opt.Identifiers,
+		Page:        opt.Page,
+		Limit:       opt.Limit,
+		OrderBy:     opt.OrderBy,
+	}
+}
+
+func ledgerOrder(o *ledger.Order) *Order {
+	return &Order{
+		UUID:            o.UUID,
+		Side:            o.Side,
+		OrdType:         o.OrdType,
+		Price:           Decimal(o.Price),
+		State:           o.State,
+		Market:          o.Market,
+		CreatedAt:       o.CreatedAt,
+		Volume:          Decimal(o.Volume),
+		RemainingVolume: Decimal(o.RemainingVolume),
+		ReservedFee:     Decimal(o.ReservedFee),
+		RemainingFee:    Decimal(o.RemainingFee),
+		PaidFee:         Decimal(o.PaidFee),
+		Locked:          Decimal(o.Locked),
+		ExecutedVolume:  Decimal(o.ExecutedVolume),
+		TradesCount:     o.TradesCount,
+	}
+}
+
+func ledgerAccount(a *ledger.Account) *Account {
+	return &Account{
+		Currency:            a.Currency,
+		Balance:             Decimal(a.Balance),
+		Locked:              Decimal(a.Locked),
+		AvgBuyPrice:         Decimal(a.AvgBuyPrice),
+		AvgBuyPriceModified: a.AvgBuyPriceModified,
+		UnitCurrency:        a.UnitCurrency,
+	}
+}
+
+func ledgerLevels(levels []BookLevel) []ledger.Level {
+	converted := make([]ledger.Level, len(levels))
+	for i, l := range levels {
+		converted[i] = ledger.Level{Price: string(l.Price), Size: string(l.Size)}
+	}
+	return converted
+}
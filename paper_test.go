@@ -0,0 +1,208 @@
+package upbit_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/investing-kr/go-upbit"
+	"github.com/investing-kr/go-upbit/upbittest"
+)
+
+func TestPaperTrader(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+	srv.AddMarket(upbit.MarketCode{Market: upbit.KRW_BTC})
+	srv.SetOrderbook(upbit.KRW_BTC,
+		[]upbit.BookLevel{{Price: "50000000", Size: "0.001"}, {Price: "51000000", Size: "1"}},
+		[]upbit.BookLevel{{Price: "49000000", Size: "1"}},
+	)
+
+	client, err := srv.Client(&upbit.ClientOptions{RateLimitPolicy: upbit.RateLimitDisabled})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "paper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	opts := &upbit.PaperTraderOptions{
+		Balances:  map[string]upbit.Decimal{"KRW": "1000000"},
+		StatePath: filepath.Join(dir, "state.json"),
+	}
+	paper, err := upbit.NewPaperTrader(client, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	order, _, err := paper.Order(ctx, &upbit.OrderRequest{
+		Market:  upbit.KRW_BTC,
+		Side:    upbit.SideBid,
+		OrdType: upbit.OrdTypePrice,
+		Price:   "101000",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 0.001 at 50,000,000 and 0.001 at 51,000,000 with the Chance's fee.
+	if order.State != upbit.OrderStateDone || !order.ExecutedVolume.Equal("0.002") || !order.PaidFee.Equal("50.5") {
+		t.Errorf("bid = %+v", order)
+	}
+
+	ask, _, err := paper.Order(ctx, &upbit.OrderRequest{
+		Market:  upbit.KRW_BTC,
+		Side:    upbit.SideAsk,
+		OrdType: upbit.OrdTypeLimit,
+		Price:   "53000000",
+		Volume:  "0.001",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ask.State != upbit.OrderStateWait {
+		t.Fatalf("ask state = %s, want wait", ask.State)
+	}
+
+	accounts := paperAccounts(t, paper)
+	if btc := accounts["BTC"]; !btc.Balance.Equal("0.001") || !btc.Locked.Equal("0.001") {
+		t.Errorf("BTC = %+v", btc)
+	}
+
+	// The trader restored from disk fills the waiting ask once the market
+	// reaches it.
+	paper, err = upbit.NewPaperTrader(client, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.SetOrderbook(upbit.KRW_BTC, nil, []upbit.BookLevel{{Price: "54000000", Size: "1"}})
+
+	ask, _, err = paper.GetOrderByUUID(ctx, ask.UUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ask.State != upbit.OrderStateDone {
+		t.Errorf("ask state = %s, want done", ask.State)
+	}
+	accounts = paperAccounts(t, paper)
+	// 1,000,000 - 101,050.5 + 54,000 - 27
+	if krw := accounts["KRW"]; !krw.Balance.Equal("952922.5") || !krw.Locked.IsZero() {
+		t.Errorf("KRW = %+v", krw)
+	}
+
+	_, _, err = paper.Order(ctx, &upbit.OrderRequest{
+		Market:  upbit.KRW_BTC,
+		Side:    upbit.SideAsk,
+		OrdType: upbit.OrdTypeMarket,
+		Volume:  "0.01",
+	})
+	if !upbit.IsInsufficientFunds(err) {
+		t.Errorf("err = %v, want insufficient funds", err)
+	}
+
+	bid, _, err := paper.Order(ctx, &upbit.OrderRequest{
+		Market:  upbit.KRW_BTC,
+		Side:    upbit.SideBid,
+		OrdType: upbit.OrdTypeLimit,
+		Price:   "40000000",
+		Volume:  "0.001",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := paper.CancelOrderByUUID(ctx, bid.UUID); err != nil {
+		t.Fatal(err)
+	}
+	orders, _, err := paper.ListOrders(ctx, &upbit.OrderListOptions{States: []string{upbit.OrderStateDone, upbit.OrderStateCancel}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 3 || orders[0].UUID != bid.UUID {
+		t.Errorf("orders = %+v", orders)
+	}
+
+	if n := len(srv.Orders()); n != 0 {
+		t.Errorf("%d real orders placed", n)
+	}
+}
+
+func paperAccounts(t *testing.T, trader upbit.Trader) map[string]*upbit.Account {
+	accounts, _, err := trader.Accounts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := map[string]*upbit.Account{}
+	for _, a := range accounts {
+		m[a.Currency] = a
+	}
+	return m
+}
+
+func TestPaperTraderTicker(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+	srv.AddMarket(upbit.MarketCode{Market: upbit.KRW_BTC})
+	srv.SetTicker(upbit.Ticker{Market: upbit.KRW_BTC, TradePrice: "50000000"})
+
+	client, err := srv.Client(&upbit.ClientOptions{RateLimitPolicy: upbit.RateLimitDisabled})
+	if err != nil {
+		t.Fatal(err)
+	}
+	paper, err := upbit.NewPaperTrader(client, &upbit.PaperTraderOptions{
+		Balances: map[string]upbit.Decimal{"KRW": "1000000"},
+		Fill:     upbit.PaperFillTicker,
+		Fee:      "0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// A new limit bid above the last trade takes it at the trade price.
+	bid, _, err := paper.Order(ctx, &upbit.OrderRequest{
+		Market:  upbit.KRW_BTC,
+		Side:    upbit.SideBid,
+		OrdType: upbit.OrdTypeLimit,
+		Price:   "52000000",
+		Volume:  "0.01",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bid.State != upbit.OrderStateDone {
+		t.Fatalf("bid state = %s, want done", bid.State)
+	}
+	if krw := paperAccounts(t, paper)["KRW"]; !krw.Balance.Equal("500000") || !krw.Locked.IsZero() {
+		t.Errorf("KRW after bid = %+v", krw)
+	}
+
+	// A resting ask crossed by a later trade fills at its limit price.
+	ask, _, err := paper.Order(ctx, &upbit.OrderRequest{
+		Market:  upbit.KRW_BTC,
+		Side:    upbit.SideAsk,
+		OrdType: upbit.OrdTypeLimit,
+		Price:   "53000000",
+		Volume:  "0.01",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ask.State != upbit.OrderStateWait {
+		t.Fatalf("ask state = %s, want wait", ask.State)
+	}
+	srv.SetTicker(upbit.Ticker{Market: upbit.KRW_BTC, TradePrice: "55000000"})
+	ask, _, err = paper.GetOrderByUUID(ctx, ask.UUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ask.State != upbit.OrderStateDone {
+		t.Errorf("ask state = %s, want done", ask.State)
+	}
+	if krw := paperAccounts(t, paper)["KRW"]; !krw.Balance.Equal("1030000") {
+		t.Errorf("KRW after ask = %+v", krw)
+	}
+}
@@ -0,0 +1,191 @@
+package upbit
+
+import "fmt"
+
+var ErrInvalidPriceUnit = fmt.Errorf("upbit: price is not a multiple of the price unit")
+
+// PriceUnitTier is a row of a price unit (호가 단위) table: prices of at least
+// MinPrice must be multiples of Unit.
+type PriceUnitTier struct {
+	MinPrice Decimal
+	Unit     Decimal
+}
+
+// PriceUnits holds the price unit table of each quote currency of Upbit KR,
+// tiers in descending MinPrice order. Upbit changes these tables from time to
+// time; replace an entry to follow a change before this package is updated.
+var PriceUnits = map[string][]PriceUnitTier{
+	"KRW": {
+		{"2000000", "1000"},
+		{"1000000", "500"},
+		{"500000", "100"},
+		{"100000", "50"},
+		{"10000", "10"},
+		{"1000", "1"},
+		{"100", "0.1"},
+		{"10", "0.01"},
+		{"1", "0.001"},
+		{"0.1", "0.0001"},
+		{"0.01", "0.00001"},
+		{"0.001", "0.000001"},
+		{"0.0001", "0.0000001"},
+		{"0", "0.00000001"},
+	},
+	"BTC": {
+		{"0", "0.00000001"},
+	},
+	"USDT": {
+		{"10", "0.01"},
+		{"1", "0.001"},
+		{"0.1", "0.0001"},
+		{"0.01", "0.00001"},
+		{"0.001", "0.000001"},
+		{"0.0001", "0.0000001"},
+		{"0", "0.00000001"},
+	},
+}
+
+// PriceUnitsSG holds the price unit tables of Upbit SG.
+var PriceUnitsSG = map[string][]PriceUnitTier{
+	"SGD": {
+		{"1000", "1"},
+		{"100", "0.1"},
+		{"10", "0.01"},
+		{"1", "0.001"},
+		{"0.1", "0.0001"},
+		{"0.01", "0.00001"},
+		{"0.001", "0.000001"},
+		{"0.0001", "0.0000001"},
+		{"0", "0.00000001"},
+	},
+	"BTC":  PriceUnits["BTC"],
+	"USDT": PriceUnits["USDT"],
+}
+
+// PriceUnitsID holds the price unit tables of Upbit ID.
+var PriceUnitsID = map[string][]PriceUnitTier{
+	"IDR": {
+		{"5000000", "1000"},
+		{"1000000", "500"},
+		{"500000", "100"},
+		{"100000", "50"},
+		{"10000", "10"},
+		{"1000", "1"},
+		{"100", "0.1"},
+		{"10", "0.01"},
+		{"0", "0.001"},
+	},
+	"BTC":  PriceUnits["BTC"],
+	"USDT": PriceUnits["USDT"],
+}
+
+type RoundingMode int
+
+const (
+	RoundNearest RoundingMode = iota
+	RoundDown
+	RoundUp
+)
+
+// PriceUnitPolicy decides what OrderService.Order does with the price of a
+// limit order.
+type PriceUnitPolicy int
+
+const (
+	// PriceUnitIgnore sends the price as is.
+	PriceUnitIgnore PriceUnitPolicy = iota
+	// PriceUnitValidate rejects a price off the price unit with
+	// ErrInvalidPriceUnit.
+	PriceUnitValidate
+	// PriceUnitRound rounds the price to the price unit in the direction
+	// favorable to the order: down for bids and up for asks.
+	PriceUnitRound
+)
+
+// PriceUnit returns the price unit of the Upbit KR market at price, or an
+// empty Decimal if the quote currency of market has no known table. Use
+// Region.PriceUnit for the other regions.
+func PriceUnit(market string, price Decimal) Decimal {
+	return priceUnit(PriceUnits, market, price)
+}
+
+func priceUnit(tables map[string][]PriceUnitTier, market string, price Decimal) Decimal {
+	quote, _, err := ParseMarket(market)
+	if err != nil {
+		return ""
+	}
+	for _, tier := range tables[quote] {
+		if !price.LessThan(tier.MinPrice) {
+			return tier.Unit
+		}
+	}
+	return ""
+}
+
+// RoundPrice rounds price to the price unit of market. price is returned
+// unchanged if the unit is unknown.
+func RoundPrice(market string, price Decimal, mode RoundingMode) Decimal {
+	return roundToUnit(price, PriceUnit(market, price), mode)
+}
+
+func roundToUnit(price, unit Decimal, mode RoundingMode) Decimal {
+	if unit.Sign() <= 0 {
+		return price
+	}
+
+	u := unit.dec()
+	q := price.dec().DivRound(u, 16)
+	switch mode {
+	case RoundDown:
+		q = q.Floor()
+	case RoundUp:
+		q = q.Ceil()
+	default:
+		q = q.Round(0)
+	}
+
+	// Rounding up may cross into a tier with a larger unit, e.g. 9999.5 to
+	// 10000, which is still a multiple of the larger unit.
+	return newDecimal(q.Mul(u))
+}
+
+// PriceUnit returns the price unit of the chance's market at price. The unit
+// reported by the API is preferred over the price unit table of the region
+// of the client that fetched the chance, Upbit KR for a Chance made by hand.
+func (c *Chance) PriceUnit(side string, price Decimal) Decimal {
+	if unit := c.reportedPriceUnit(side); unit.Sign() > 0 {
+		return unit
+	}
+	return c.region.PriceUnit(c.Market.ID, price)
+}
+
+func (c *Chance) reportedPriceUnit(side string) Decimal {
+	if side == SideAsk {
+		return c.Market.Ask.PriceUnit
+	}
+	return c.Market.Bid.PriceUnit
+}
+
+// normalizePrice applies policy to the price of a limit order using unit.
+func normalizePrice(orderReq *OrderRequest, unit Decimal, policy PriceUnitPolicy) (*OrderRequest, error) {
+	if policy == PriceUnitIgnore || orderReq.OrdType != OrdTypeLimit || orderReq.Price == "" || unit.Sign() <= 0 {
+		return orderReq, nil
+	}
+
+	mode := RoundDown
+	if orderReq.Side == SideAsk {
+		mode = RoundUp
+	}
+
+	rounded := roundToUnit(orderReq.Price, unit, mode)
+	if rounded.Equal(orderReq.Price) {
+		return orderReq, nil
+	}
+	if policy == PriceUnitValidate {
+		return nil, ErrInvalidPriceUnit
+	}
+
+	normalized := *orderReq
+	normalized.Price = rounded
+	return &normalized, nil
+}
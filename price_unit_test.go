@@ -0,0 +1,111 @@
+package upbit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+func TestPriceUnit(t *testing.T) {
+	for _, tc := range []struct {
+		market string
+		price  upbit.Decimal
+		unit   upbit.Decimal
+	}{
+		{upbit.KRW_BTC, "43512000", "1000"},
+		{upbit.KRW_BTC, "2000000", "1000"},
+		{upbit.KRW_BTC, "1999999", "500"},
+		{upbit.KRW_ETH, "150000", "50"},
+		{upbit.KRW_ETH, "5500", "1"},
+		{upbit.KRW_ETH, "5.5", "0.001"},
+		{upbit.BTC_ETH, "0.05", "0.00000001"},
+		{"XYZ-ABC", "1", ""},
+	} {
+		if unit := upbit.PriceUnit(tc.market, tc.price); unit != tc.unit {
+			t.Errorf("PriceUnit(%s, %s) = %s, want %s", tc.market, tc.price, unit, tc.unit)
+		}
+	}
+}
+
+func TestRoundPrice(t *testing.T) {
+	for _, tc := range []struct {
+		price upbit.Decimal
+		mode  upbit.RoundingMode
+		want  upbit.Decimal
+	}{
+		{"43512345", upbit.RoundDown, "43512000"},
+		{"43512345", upbit.RoundUp, "43513000"},
+		{"43512500", upbit.RoundNearest, "43513000"},
+		{"150025", upbit.RoundDown, "150000"},
+		{"150025", upbit.RoundNearest, "150050"},
+		{"9999.5", upbit.RoundUp, "10000"},
+		{"123.456", upbit.RoundDown, "123.4"},
+		{"1000", upbit.RoundUp, "1000"},
+	} {
+		if got := upbit.RoundPrice(upbit.KRW_BTC, tc.price, tc.mode); !got.Equal(tc.want) {
+			t.Errorf("RoundPrice(%s, %d) = %s, want %s", tc.price, tc.mode, got, tc.want)
+		}
+	}
+}
+
+func TestOrderPriceUnitPolicy(t *testing.T) {
+	var price string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		price = r.URL.Query().Get("price")
+		w.Write([]byte(`{"uuid":"u"}`))
+	}))
+	defer srv.Close()
+
+	orderReq := &upbit.OrderRequest{
+		Market:  upbit.KRW_BTC,
+		Side:    upbit.SideAsk,
+		Volume:  "0.01",
+		Price:   "43512345",
+		OrdType: upbit.OrdTypeLimit,
+	}
+
+	client, _ := upbit.NewClient(nil, &upbit.ClientOptions{ServerURL: srv.URL, PriceUnitPolicy: upbit.PriceUnitValidate})
+	if _, _, err := client.Orders.Order(context.Background(), orderReq); !errors.Is(err, upbit.ErrInvalidPriceUnit) {
+		t.Errorf("validate err = %v", err)
+	}
+
+	client, _ = upbit.NewClient(nil, &upbit.ClientOptions{ServerURL: srv.URL, PriceUnitPolicy: upbit.PriceUnitRound})
+	if _, _, err := client.Orders.Order(context.Background(), orderReq); err != nil {
+		t.Fatal(err)
+	}
+	if price != "43513000" {
+		t.Errorf("ask price sent = %s, want rounded up to 43513000", price)
+	}
+	if orderReq.Price != "43512345" {
+		t.Error("Order modified the caller's request")
+	}
+
+	for _, policy := range []upbit.PriceUnitPolicy{upbit.PriceUnitIgnore, upbit.PriceUnitValidate} {
+		client, _ = upbit.NewClient(nil, &upbit.ClientOptions{ServerURL: srv.URL, PriceUnitPolicy: policy})
+		bad := *orderReq
+		bad.Price = "1,000"
+		if _, _, err := client.Orders.Order(context.Background(), &bad); !errors.Is(err, upbit.ErrInvalidArguments) {
+			t.Errorf("policy %d, price %s: err = %v, want %v", policy, bad.Price, err, upbit.ErrInvalidArguments)
+		}
+	}
+}
+
+func TestChancePriceUnitRegion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"market":{"id":"SGD-BTC","bid":{"currency":"SGD"},"ask":{"currency":"BTC"}}}`))
+	}))
+	defer srv.Close()
+
+	client, _ := upbit.NewClient(nil, &upbit.ClientOptions{ServerURL: srv.URL, Region: upbit.RegionSG})
+	chance, _, err := client.Orders.Chances(context.Background(), "SGD-BTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unit := chance.PriceUnit(upbit.SideBid, "45000"); unit != "1" {
+		t.Errorf("PriceUnit = %s, want the SGD unit 1", unit)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/google/go-querystring/query"
 )
 
+var ErrMarketNotFound = fmt.Errorf("upbit: market not found")
+
 func (s *MarketService) All(ctx context.Context) ([]*MarketCode, *http.Response, error) {
 	u := fmt.Sprintf("v1/market/all?isDetail=true")
 	req, err := s.client.NewRequest(http.MethodGet, u, nil)
@@ -23,7 +25,7 @@ func (s *MarketService) All(ctx context.Context) ([]*MarketCode, *http.Response,
 		return nil, resp, err
 	}
 
-	return markets, nil, nil
+	return markets, resp, nil
 }
 
 type CandleListOptions struct {
@@ -53,23 +55,29 @@ func (s *CandleService) candle(ctx context.Context, path string, market string,
 		return nil, resp, err
 	}
 
-	return candles, nil, nil
+	return candles, resp, nil
 }
 
+// CandleMinutes returns minute candles of unit, which must be one of 1, 3, 5,
+// 10, 15, 30, 60 and 240.
 func (s *CandleService) CandleMinutes(ctx context.Context, market string, unit int, opts *CandleListOptions) ([]*Candle, *http.Response, error) {
-	return s.candle(ctx, fmt.Sprintf("minutes/%d", unit), market, opts)
+	interval, err := MinuteInterval(unit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.Candles(ctx, market, interval, opts)
 }
 
 func (s *CandleService) CandleDays(ctx context.Context, market string, opts *CandleListOptions) ([]*Candle, *http.Response, error) {
-	return s.candle(ctx, "days", market, opts)
+	return s.Candles(ctx, market, Day, opts)
 }
 
 func (s *CandleService) CandleWeeks(ctx context.Context, market string, opts *CandleListOptions) ([]*Candle, *http.Response, error) {
-	return s.candle(ctx, "weeks", market, opts)
+	return s.Candles(ctx, market, Week, opts)
 }
 
 func (s *CandleService) CandleMonths(ctx context.Context, market string, opts *CandleListOptions) ([]*Candle, *http.Response, error) {
-	return s.candle(ctx, "months", market, opts)
+	return s.Candles(ctx, market, Month, opts)
 }
 
 func (s *CandleService) Ticker(ctx context.Context, markets []string) ([]*Ticker, *http.Response, error) {
@@ -90,7 +98,7 @@ func (s *CandleService) Ticker(ctx context.Context, markets []string) ([]*Ticker
 		return nil, resp, err
 	}
 
-	return tickers, nil, nil
+	return tickers, resp, nil
 }
 
 func (s *CandleService) TickerMarket(ctx context.Context, market string) (*Ticker, *http.Response, error) {
@@ -101,3 +109,37 @@ func (s *CandleService) TickerMarket(ctx context.Context, market string) (*Ticke
 
 	return lst[0], resp, err
 }
+
+func (s *QuotationService) Orderbook(ctx context.Context, markets []string) ([]*Orderbook, *http.Response, error) {
+	if len(markets) == 0 {
+		return nil, nil, ErrInvalidArguments
+	}
+
+	u := fmt.Sprintf("v1/orderbook?markets=%s", strings.Join(markets, ","))
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var orderbooks []*Orderbook
+
+	resp, err := s.client.Do(ctx, req, &orderbooks)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return orderbooks, resp, nil
+}
+
+func (s *QuotationService) OrderbookMarket(ctx context.Context, market string) (*Orderbook, *http.Response, error) {
+	lst, resp, err := s.Orderbook(ctx, []string{market})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(lst) == 0 {
+		return nil, resp, ErrMarketNotFound
+	}
+
+	return lst[0], resp, nil
+}
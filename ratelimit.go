@@ -0,0 +1,234 @@
+package upbit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrRateLimited = fmt.Errorf("upbit: rate limit exceeded")
+
+type RateLimitPolicy int
+
+const (
+	// RateLimitBlock waits until the group has budget for the request.
+	RateLimitBlock RateLimitPolicy = iota
+	// RateLimitFailFast returns ErrRateLimited instead of waiting.
+	RateLimitFailFast
+	// RateLimitDisabled sends every request immediately.
+	RateLimitDisabled
+)
+
+const (
+	RateLimitGroupDefault   string = "default"
+	RateLimitGroupOrder     string = "order"
+	RateLimitGroupMarket    string = "market"
+	RateLimitGroupCandles   string = "candles"
+	RateLimitGroupTicker    string = "ticker"
+	RateLimitGroupOrderbook string = "orderbook"
+	RateLimitGroupTrades    string = "trades"
+)
+
+// Requests per second allowed by Upbit for each group.
+var defaultRateLimits = map[string]int{
+	RateLimitGroupDefault:   30,
+	RateLimitGroupOrder:     8,
+	RateLimitGroupMarket:    10,
+	RateLimitGroupCandles:   10,
+	RateLimitGroupTicker:    10,
+	RateLimitGroupOrderbook: 10,
+	RateLimitGroupTrades:    10,
+}
+
+// RateLimit is the remaining request budget reported by the Remaining-Req
+// header, e.g. "group=default; min=1799; sec=29". Min is -1 if the server
+// did not report it.
+type RateLimit struct {
+	Group string
+	Min   int
+	Sec   int
+}
+
+// ParseRateLimit parses the Remaining-Req header of resp. It returns nil if
+// the header is absent.
+func ParseRateLimit(resp *http.Response) (*RateLimit, error) {
+	if resp == nil {
+		return nil, nil
+	}
+
+	header := resp.Header.Get("Remaining-Req")
+	if header == "" {
+		return nil, nil
+	}
+
+	rl := &RateLimit{Min: -1, Sec: -1}
+	for _, field := range strings.Split(header, ";") {
+		kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		var err error
+		switch kv[0] {
+		case "group":
+			rl.Group = kv[1]
+		case "min":
+			rl.Min, err = strconv.Atoi(kv[1])
+		case "sec":
+			rl.Sec, err = strconv.Atoi(kv[1])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("upbit: invalid Remaining-Req %q: %v", header, err)
+		}
+	}
+
+	if rl.Group == "" || rl.Sec < 0 {
+		return nil, fmt.Errorf("upbit: invalid Remaining-Req %q", header)
+	}
+	return rl, nil
+}
+
+func rateLimitGroup(method, path string) string {
+	path = strings.TrimPrefix(path, "/")
+	switch {
+	case method == http.MethodPost && path == "v1/orders":
+		return RateLimitGroupOrder
+	case strings.HasPrefix(path, "v1/market/"):
+		return RateLimitGroupMarket
+	case strings.HasPrefix(path, "v1/candles/"):
+		return RateLimitGroupCandles
+	case path == "v1/ticker":
+		return RateLimitGroupTicker
+	case path == "v1/orderbook":
+		return RateLimitGroupOrderbook
+	case strings.HasPrefix(path, "v1/trades/"):
+		return RateLimitGroupTrades
+	}
+	return RateLimitGroupDefault
+}
+
+type bucket struct {
+	tokens   float64
+	capacity float64
+	last     time.Time
+}
+
+func (b *bucket) refill(now time.Time) {
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.capacity)
+	b.last = now
+}
+
+// rateLimiter keeps a token bucket per group, refilled at the group's
+// per-second limit and corrected by every Remaining-Req header.
+type rateLimiter struct {
+	policy RateLimitPolicy
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	last    map[string]*RateLimit
+}
+
+func newRateLimiter(policy RateLimitPolicy) *rateLimiter {
+	return &rateLimiter{
+		policy:  policy,
+		buckets: map[string]*bucket{},
+		last:    map[string]*RateLimit{},
+	}
+}
+
+func (l *rateLimiter) bucket(group string, now time.Time) *bucket {
+	b, ok := l.buckets[group]
+	if !ok {
+		limit, ok := defaultRateLimits[group]
+		if !ok {
+			limit = defaultRateLimits[RateLimitGroupDefault]
+		}
+		b = &bucket{tokens: float64(limit), capacity: float64(limit), last: now}
+		l.buckets[group] = b
+	}
+	b.refill(now)
+	return b
+}
+
+// wait takes a token of group, waiting for it unless the policy is
+// RateLimitFailFast.
+func (l *rateLimiter) wait(ctx context.Context, group string) error {
+	if l.policy == RateLimitDisabled {
+		return nil
+	}
+
+	l.mu.Lock()
+	b := l.bucket(group, time.Now())
+	if b.tokens < 1 && l.policy == RateLimitFailFast {
+		l.mu.Unlock()
+		return ErrRateLimited
+	}
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.capacity * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		b.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (l *rateLimiter) update(rl *RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.last[rl.Group] = rl
+
+	b := l.bucket(rl.Group, time.Now())
+	if capacity := float64(rl.Sec + 1); capacity > b.capacity {
+		b.capacity = capacity
+	}
+	remaining := float64(rl.Sec)
+	if rl.Min == 0 {
+		remaining = 0
+	}
+	if b.tokens > remaining {
+		b.tokens = remaining
+	}
+}
+
+// exhausted drains the group after the server answered 429.
+func (l *rateLimiter) exhausted(group string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(group, time.Now())
+	if b.tokens > 0 {
+		b.tokens = 0
+	}
+}
+
+// RateLimit returns the last Remaining-Req reported for group, or nil.
+func (c *Client) RateLimit(group string) *RateLimit {
+	c.limiter.mu.Lock()
+	defer c.limiter.mu.Unlock()
+
+	rl, ok := c.limiter.last[group]
+	if !ok {
+		return nil
+	}
+	copied := *rl
+	return &copied
+}
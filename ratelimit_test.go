@@ -0,0 +1,71 @@
+package upbit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Remaining-Req", "group=default; min=1799; sec=29")
+
+	rl, err := upbit.ParseRateLimit(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rl.Group != "default" || rl.Min != 1799 || rl.Sec != 29 {
+		t.Errorf("ParseRateLimit = %+v", rl)
+	}
+
+	resp.Header.Set("Remaining-Req", "group=market; sec=9")
+	rl, err = upbit.ParseRateLimit(resp)
+	if err != nil || rl.Group != "market" || rl.Min != -1 || rl.Sec != 9 {
+		t.Errorf("ParseRateLimit = %+v, %v", rl, err)
+	}
+
+	resp.Header.Set("Remaining-Req", "group=market; sec=x")
+	if _, err := upbit.ParseRateLimit(resp); err == nil {
+		t.Error("ParseRateLimit accepted an invalid header")
+	}
+}
+
+func TestRateLimitFailFast(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Remaining-Req", "group=default; min=1799; sec=0")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	client, err := upbit.NewClient(nil, &upbit.ClientOptions{
+		ServerURL:       srv.URL,
+		RateLimitPolicy: upbit.RateLimitFailFast,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	_, resp, err := client.Accounts.Accounts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rl, err := upbit.ParseRateLimit(resp); err != nil || rl.Group != upbit.RateLimitGroupDefault || rl.Min != 1799 || rl.Sec != 0 {
+		t.Errorf("ParseRateLimit = %+v, %v", rl, err)
+	}
+	if rl := client.RateLimit(upbit.RateLimitGroupDefault); rl == nil || rl.Sec != 0 {
+		t.Errorf("RateLimit = %+v", rl)
+	}
+	if _, _, err := client.Accounts.Accounts(ctx); !errors.Is(err, upbit.ErrRateLimited) {
+		t.Errorf("second request err = %v, want ErrRateLimited", err)
+	}
+
+	// Quotation groups have their own budget.
+	if _, _, err := client.Markets.All(ctx); err != nil {
+		t.Error(err)
+	}
+}
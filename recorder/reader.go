@@ -0,0 +1,203 @@
+package recorder
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/investing-kr/go-upbit/backtest"
+)
+
+// Files returns the files of market in dir that may hold events in
+// [from, to), oldest first. A zero from or to leaves that side open.
+func Files(dir, market string, from, to time.Time) ([]string, error) {
+	paths, _, err := files(dir, market, from, to)
+	return paths, err
+}
+
+// files is Files, also returning the newest file of market, which may still
+// be being recorded.
+func files(dir, market string, from, to time.Time) (paths []string, newest string, err error) {
+	days, err := ioutil.ReadDir(filepath.Join(dir, market))
+	if os.IsNotExist(err) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	type entry struct {
+		path  string
+		start time.Time
+	}
+	var entries []entry
+	for _, day := range days {
+		if !day.IsDir() {
+			continue
+		}
+		date, err := time.Parse(dateLayout, day.Name())
+		if err != nil {
+			continue
+		}
+		infos, err := ioutil.ReadDir(filepath.Join(dir, market, day.Name()))
+		if err != nil {
+			return nil, "", err
+		}
+		for _, f := range infos {
+			name := f.Name()
+			if f.IsDir() || !strings.HasSuffix(name, fileSuffix) {
+				continue
+			}
+			clock, err := time.Parse(fileLayout, strings.TrimSuffix(name, fileSuffix))
+			if err != nil {
+				continue
+			}
+			start := date.Add(clock.Sub(time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)))
+			entries = append(entries, entry{filepath.Join(dir, market, day.Name(), name), start})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].start.Before(entries[j].start) })
+
+	if len(entries) > 0 {
+		newest = entries[len(entries)-1].path
+	}
+
+	// A file covers the time up to the start of the next one.
+	for i, e := range entries {
+		if !to.IsZero() && !e.start.Before(to) {
+			break
+		}
+		if !from.IsZero() && i+1 < len(entries) && !entries[i+1].start.After(from) {
+			continue
+		}
+		paths = append(paths, e.path)
+	}
+	return paths, newest, nil
+}
+
+// Reader reads back the events of a recording, merged chronologically across
+// markets. It is a backtest.Source.
+type Reader struct {
+	src      backtest.Source
+	files    []*fileSource
+	from, to time.Time
+}
+
+// Open returns a Reader of the events of markets recorded in dir in
+// [from, to). A zero from or to leaves that side open.
+func Open(dir string, markets []string, from, to time.Time) (*Reader, error) {
+	r := &Reader{from: from, to: to}
+	sources := make([]backtest.Source, 0, len(markets))
+	for _, market := range markets {
+		paths, newest, err := files(dir, market, from, to)
+		if err != nil {
+			return nil, err
+		}
+		f := &fileSource{paths: paths, newest: newest}
+		r.files = append(r.files, f)
+		sources = append(sources, f)
+	}
+	r.src = backtest.Merge(sources...)
+	return r, nil
+}
+
+// Next returns the next event in the range, or io.EOF.
+func (r *Reader) Next() (*backtest.Event, error) {
+	for {
+		e, err := r.src.Next()
+		if err != nil {
+			return nil, err
+		}
+		t := e.Time()
+		if !r.from.IsZero() && t.Before(r.from) {
+			continue
+		}
+		if !r.to.IsZero() && !t.Before(r.to) {
+			// Events of other markets may still be in range.
+			continue
+		}
+		return e, nil
+	}
+}
+
+func (r *Reader) Close() error {
+	var err error
+	for _, f := range r.files {
+		if cerr := f.close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// fileSource reads files one after another. The newest file of a market
+// may still be being recorded and ends at its last flush, in the middle of
+// a gzip stream; any other file that ends early is corrupt.
+type fileSource struct {
+	paths  []string
+	newest string
+
+	path string
+	r    *backtest.Reader
+}
+
+func (s *fileSource) Next() (*backtest.Event, error) {
+	for {
+		if s.r == nil {
+			if len(s.paths) == 0 {
+				return nil, io.EOF
+			}
+			s.path = s.paths[0]
+			s.paths = s.paths[1:]
+			r, err := backtest.Open(s.path)
+			if err == io.EOF {
+				// Nothing was flushed to the file yet.
+				continue
+			}
+			if err != nil {
+				if s.live(err) {
+					continue
+				}
+				return nil, s.err(err)
+			}
+			s.r = r
+		}
+
+		e, err := s.r.Next()
+		if err == io.EOF || s.live(err) {
+			// Closing a truncated file reports the truncation again.
+			if cerr := s.close(); cerr != nil && !s.live(cerr) {
+				return nil, s.err(cerr)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, s.err(err)
+		}
+		return e, nil
+	}
+}
+
+// live reports whether err is the end of the newest file at its last flush.
+func (s *fileSource) live(err error) bool {
+	return s.path == s.newest && errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func (s *fileSource) err(err error) error {
+	return fmt.Errorf("recorder: %s: %w", s.path, err)
+}
+
+func (s *fileSource) close() error {
+	if s.r == nil {
+		return nil
+	}
+	err := s.r.Close()
+	s.r = nil
+	return err
+}
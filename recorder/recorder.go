@@ -0,0 +1,242 @@
+// Package recorder captures Upbit market data streams to disk and reads them
+// back.
+//
+// A Recorder subscribes to the ticker, trade and orderbook streams of a set
+// of markets and writes every event as a JSON line in the format of package
+// backtest, so that recordings replay directly in a backtest. Files are
+// gzip-compressed and partitioned by market and UTC date, and a new file is
+// started every RotateInterval:
+//
+//	dir/KRW-BTC/2021-01-01/000000.jsonl.gz
+//	dir/KRW-BTC/2021-01-01/010000.jsonl.gz
+//
+// Open reads the files of a time range back as *backtest.Event values
+// holding the same Ticker, Orderbook and TradeTick structs the client
+// returns.
+package recorder
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+	"github.com/investing-kr/go-upbit/backtest"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	fileLayout = "150405"
+	fileSuffix = ".jsonl.gz"
+)
+
+type Options struct {
+	// Dir is the root directory of the recording.
+	Dir string
+
+	Markets []string
+
+	// Types are the stream types to record. Defaults to ticker, trade and
+	// orderbook.
+	Types []string
+
+	// RotateInterval is how long a file covers. It should divide a day.
+	// Defaults to an hour.
+	RotateInterval time.Duration
+
+	// FlushInterval is how often buffered events are written out, bounding
+	// what a crash loses. Defaults to 5 seconds.
+	FlushInterval time.Duration
+
+	Supervisor *upbit.SupervisorOptions
+
+	// OnStatus, if set, is called with the disconnections and reconnections
+	// of the stream, e.g. to log gaps in the recording.
+	OnStatus func(upbit.StreamStatus)
+}
+
+// Recorder writes market events to files partitioned by market and date.
+// Write may be called concurrently with Run.
+type Recorder struct {
+	client *upbit.Client
+	opts   Options
+
+	mu    sync.Mutex
+	files map[string]*file
+}
+
+type file struct {
+	start time.Time
+	f     *os.File
+	gz    *gzip.Writer
+	w     *backtest.Writer
+}
+
+// New returns a Recorder streaming from client. The client is not used by
+// Write, so it may be nil when events come from elsewhere.
+func New(client *upbit.Client, opts *Options) (*Recorder, error) {
+	r := &Recorder{client: client, files: map[string]*file{}}
+	if opts != nil {
+		r.opts = *opts
+	}
+	if r.opts.Dir == "" {
+		return nil, fmt.Errorf("recorder: no directory")
+	}
+	if len(r.opts.Types) == 0 {
+		r.opts.Types = []string{upbit.WebsocketTypeTicker, upbit.WebsocketTypeTrade, upbit.WebsocketTypeOrderbook}
+	}
+	if r.opts.RotateInterval <= 0 {
+		r.opts.RotateInterval = time.Hour
+	}
+	if r.opts.FlushInterval <= 0 {
+		r.opts.FlushInterval = 5 * time.Second
+	}
+	return r, nil
+}
+
+// Run records the streams of the markets until ctx is done, reconnecting as
+// needed, and then closes the files.
+func (r *Recorder) Run(ctx context.Context) error {
+	if r.client == nil {
+		return fmt.Errorf("recorder: no client")
+	}
+	if len(r.opts.Markets) == 0 {
+		return fmt.Errorf("recorder: no markets")
+	}
+
+	types := make([]upbit.WebsocketRequestType, len(r.opts.Types))
+	for i, typ := range r.opts.Types {
+		types[i] = upbit.WebsocketRequestType{Type: typ, Codes: r.opts.Markets}
+	}
+	sv := r.client.Streams.Supervise(ctx, r.opts.Supervisor, types...)
+	defer sv.Close()
+
+	flush := time.NewTicker(r.opts.FlushInterval)
+	defer flush.Stop()
+
+	for {
+		var (
+			e   *backtest.Event
+			err error
+		)
+		select {
+		case <-ctx.Done():
+			return r.Close()
+		case <-sv.Done():
+			r.Close()
+			return ctx.Err()
+		case <-flush.C:
+			err = r.Flush()
+		case status := <-sv.Status():
+			if r.opts.OnStatus != nil {
+				r.opts.OnStatus(status)
+			}
+		case ticker := <-sv.Ticker():
+			e = &backtest.Event{Ticker: ticker}
+		case trade := <-sv.Trade():
+			e = &backtest.Event{Trade: trade.Tick()}
+		case ob := <-sv.Orderbook():
+			e = &backtest.Event{Orderbook: ob}
+		}
+		if e != nil {
+			err = r.Write(e)
+		}
+		if err != nil {
+			r.Close()
+			return err
+		}
+	}
+}
+
+// Write appends e to the file of its market and time, starting a new file
+// when the time crosses into the next RotateInterval. Events arriving late
+// go to the current file.
+func (r *Recorder) Write(e *backtest.Event) error {
+	market := e.Market()
+	if market == "" {
+		return fmt.Errorf("recorder: %s event without a market", e.Type())
+	}
+	start := e.Time().Truncate(r.opts.RotateInterval)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f := r.files[market]
+	if f == nil || start.After(f.start) {
+		if f != nil {
+			if err := f.close(); err != nil {
+				return err
+			}
+			delete(r.files, market)
+		}
+
+		var err error
+		if f, err = r.open(market, start); err != nil {
+			return err
+		}
+		r.files[market] = f
+	}
+	return f.w.Write(e)
+}
+
+// Path returns the file of market starting at start.
+func Path(dir, market string, start time.Time) string {
+	start = start.UTC()
+	return filepath.Join(dir, market, start.Format(dateLayout), start.Format(fileLayout)+fileSuffix)
+}
+
+// open opens the file of market starting at start. A file left by a
+// previous run is appended to as another gzip member, which readers
+// decompress as one stream.
+func (r *Recorder) open(market string, start time.Time) (*file, error) {
+	path := Path(r.opts.Dir, market, start)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	gz := gzip.NewWriter(f)
+	return &file{start: start, f: f, gz: gz, w: backtest.NewWriter(gz)}, nil
+}
+
+// Flush writes out the buffered events of all files.
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, f := range r.files {
+		if err := f.gz.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes all files. Write opens them again.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var err error
+	for market, f := range r.files {
+		if cerr := f.close(); err == nil {
+			err = cerr
+		}
+		delete(r.files, market)
+	}
+	return err
+}
+
+func (f *file) close() error {
+	err := f.gz.Close()
+	if cerr := f.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
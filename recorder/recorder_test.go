@@ -0,0 +1,260 @@
+package recorder_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/investing-kr/go-upbit"
+	"github.com/investing-kr/go-upbit/backtest"
+	"github.com/investing-kr/go-upbit/recorder"
+)
+
+func ms(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func readAll(t *testing.T, r *recorder.Reader) []*backtest.Event {
+	t.Helper()
+	var events []*backtest.Event
+	for {
+		e, err := r.Next()
+		if err == io.EOF {
+			return events
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, e)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recorder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rec, err := recorder.New(nil, &recorder.Options{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day := time.Date(2021, 1, 1, 23, 0, 0, 0, time.UTC)
+	events := []*backtest.Event{
+		{Ticker: &upbit.Ticker{Market: upbit.KRW_BTC, TradePrice: "100", TradeTimestamp: ms(day.Add(10 * time.Minute))}},
+		{Trade: &upbit.TradeTick{Market: upbit.KRW_ETH, TradePrice: "10", TradeVolume: "1", Timestamp: ms(day.Add(20 * time.Minute))}},
+		{Orderbook: &upbit.Orderbook{Market: upbit.KRW_BTC, Timestamp: ms(day.Add(30 * time.Minute))}},
+		// The next day, in a new file.
+		{Ticker: &upbit.Ticker{Market: upbit.KRW_BTC, TradePrice: "101", TradeTimestamp: ms(day.Add(70 * time.Minute))}},
+	}
+	for _, e := range events[:3] {
+		if err := rec.Write(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// A restart appends to the same file.
+	if err := rec.Write(events[3]); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Write(&backtest.Event{Ticker: &upbit.Ticker{Market: upbit.KRW_BTC, TradeTimestamp: ms(day.Add(40 * time.Minute))}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := recorder.Files(dir, upbit.KRW_BTC, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		filepath.Join(dir, "KRW-BTC", "2021-01-01", "230000.jsonl.gz"),
+		filepath.Join(dir, "KRW-BTC", "2021-01-02", "000000.jsonl.gz"),
+	}
+	if len(files) != 2 || files[0] != want[0] || files[1] != want[1] {
+		t.Errorf("files = %v, want %v", files, want)
+	}
+
+	r, err := recorder.Open(dir, []string{upbit.KRW_BTC, upbit.KRW_ETH}, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := readAll(t, r)
+	r.Close()
+	// The late ticker went to the current file, after the ticker of the next
+	// day.
+	if len(got) != 5 || got[0].Ticker == nil || got[1].Trade == nil || got[1].Trade.TradeVolume != "1" ||
+		got[2].Orderbook == nil || got[3].Ticker == nil || got[3].Ticker.TradePrice != "101" || got[4].Ticker == nil {
+		t.Errorf("events = %+v", got)
+	}
+
+	r, err = recorder.Open(dir, []string{upbit.KRW_BTC}, day.Add(25*time.Minute), day.Add(90*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = readAll(t, r)
+	r.Close()
+	if len(got) != 3 || got[0].Orderbook == nil || got[1].Ticker == nil || got[2].Ticker == nil {
+		t.Errorf("events in range = %+v", got)
+	}
+}
+
+func TestReaderTruncated(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recorder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rec, err := recorder.New(nil, &recorder.Options{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	write := func(hour, from, to int) {
+		at := time.Date(2021, 1, 1, hour, 0, 0, 0, time.UTC)
+		for i := from; i < to; i++ {
+			e := &backtest.Event{Ticker: &upbit.Ticker{Market: upbit.KRW_BTC, TradePrice: "100", TradeTimestamp: ms(at.Add(time.Duration(i) * time.Second))}}
+			if err := rec.Write(e); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	write(0, 0, 100)
+	write(1, 0, 100)
+	write(2, 0, 50)
+	if err := rec.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	files, err := recorder.Files(dir, upbit.KRW_BTC, time.Time{}, time.Time{})
+	if err != nil || len(files) != 3 {
+		t.Fatalf("files = %v, %v", files, err)
+	}
+	info, err := os.Stat(files[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	flushed := info.Size()
+	write(2, 50, 100)
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The newest file may still be being recorded and ends at its last
+	// flush.
+	if err := os.Truncate(files[2], flushed); err != nil {
+		t.Fatal(err)
+	}
+	r, err := recorder.Open(dir, []string{upbit.KRW_BTC}, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := readAll(t, r)
+	r.Close()
+	if len(got) != 250 {
+		t.Errorf("read %d events, want 250 up to the last flush", len(got))
+	}
+
+	// A finished file that ends early is corrupt.
+	info, err = os.Stat(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(files[0], info.Size()/2); err != nil {
+		t.Fatal(err)
+	}
+	r, err = recorder.Open(dir, []string{upbit.KRW_BTC}, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	for err == nil {
+		_, err = r.Next()
+	}
+	if err == io.EOF || !strings.Contains(err.Error(), files[0]) {
+		t.Errorf("err = %v, want an error of %s", err, files[0])
+	}
+}
+
+func TestRun(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+		for _, m := range []string{
+			`{"type":"ticker","code":"KRW-BTC","trade_price":100.5,"trade_timestamp":1609459200000}`,
+			`{"type":"trade","code":"KRW-BTC","trade_price":100.5,"trade_volume":0.1,"trade_timestamp":1609459201000,"sequential_id":42}`,
+		} {
+			if err := conn.WriteMessage(websocket.BinaryMessage, []byte(m)); err != nil {
+				return
+			}
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	client, err := upbit.NewClient(nil, &upbit.ClientOptions{ServerURL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "recorder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rec, err := recorder.New(client, &recorder.Options{
+		Dir:           dir,
+		Markets:       []string{upbit.KRW_BTC},
+		FlushInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- rec.Run(ctx) }()
+
+	// The file being recorded is readable up to its last flush.
+	var got []*backtest.Event
+	for len(got) < 2 && ctx.Err() == nil {
+		time.Sleep(10 * time.Millisecond)
+		r, err := recorder.Open(dir, []string{upbit.KRW_BTC}, time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = readAll(t, r)
+		r.Close()
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+
+	if len(got) != 2 || got[0].Ticker == nil || got[1].Trade == nil || got[1].Trade.SequentialID != 42 || got[1].Trade.Market != upbit.KRW_BTC {
+		t.Errorf("events = %+v", got)
+	}
+}
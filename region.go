@@ -0,0 +1,104 @@
+package upbit
+
+import "fmt"
+
+// Region selects the Upbit exchange a Client talks to. The global exchanges
+// of Singapore and Indonesia serve a subset of the Korean API, quote in SGD
+// and IDR and use their own price units.
+type Region string
+
+const (
+	RegionKR Region = "kr"
+	RegionSG Region = "sg"
+	RegionID Region = "id"
+)
+
+// feature is an API a region may not serve.
+type feature string
+
+const (
+	featureKRW           feature = "KRW deposits and withdrawals"
+	featurePrivateStream feature = "private WebSocket"
+)
+
+type regionProfile struct {
+	serverURL   string
+	quotes      []string
+	unsupported []feature
+}
+
+var regionProfiles = map[Region]*regionProfile{
+	RegionKR: {
+		serverURL: UpbitKR,
+		quotes:    []string{"KRW", "BTC", "USDT"},
+	},
+	RegionSG: {
+		serverURL:   UpbitSG,
+		quotes:      []string{"SGD", "BTC", "USDT"},
+		unsupported: []feature{featureKRW, featurePrivateStream},
+	},
+	RegionID: {
+		serverURL:   UpbitID,
+		quotes:      []string{"IDR", "BTC", "USDT"},
+		unsupported: []feature{featureKRW, featurePrivateStream},
+	},
+}
+
+func (r Region) profile() (*regionProfile, error) {
+	if r == "" {
+		r = RegionKR
+	}
+	p, ok := regionProfiles[r]
+	if !ok {
+		return nil, fmt.Errorf("upbit: unknown region %q", string(r))
+	}
+	return p, nil
+}
+
+// QuoteCurrencies returns the quote currencies of the region's markets.
+func (r Region) QuoteCurrencies() []string {
+	p, err := r.profile()
+	if err != nil {
+		return nil
+	}
+	return append([]string(nil), p.quotes...)
+}
+
+// PriceUnits returns the price unit tables of the region by quote currency.
+func (r Region) PriceUnits() map[string][]PriceUnitTier {
+	switch r {
+	case RegionSG:
+		return PriceUnitsSG
+	case RegionID:
+		return PriceUnitsID
+	}
+	return PriceUnits
+}
+
+// PriceUnit is PriceUnit for the markets of the region.
+func (r Region) PriceUnit(market string, price Decimal) Decimal {
+	return priceUnit(r.PriceUnits(), market, price)
+}
+
+// RoundPrice is RoundPrice for the markets of the region.
+func (r Region) RoundPrice(market string, price Decimal, mode RoundingMode) Decimal {
+	return roundToUnit(price, r.PriceUnit(market, price), mode)
+}
+
+func (c *Client) Region() Region {
+	return c.region
+}
+
+// supports returns ErrNotImplemented if the client's region does not serve f.
+func (c *Client) supports(f feature) error {
+	p, err := c.region.profile()
+	if err != nil {
+		return err
+	}
+	for _, u := range p.unsupported {
+		if u == f {
+			return fmt.Errorf("%w: %s in region %s", ErrNotImplemented, f, string(c.region))
+		}
+	}
+	return nil
+}
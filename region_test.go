@@ -0,0 +1,50 @@
+package upbit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+func TestRegion(t *testing.T) {
+	if _, err := upbit.NewClient(nil, &upbit.ClientOptions{Region: "jp"}); err == nil {
+		t.Error("unknown region accepted")
+	}
+
+	client, err := upbit.NewClient(nil, &upbit.ClientOptions{
+		AccessKey: "access",
+		SecretKey: "secret",
+		Region:    upbit.RegionSG,
+		ServerURL: "http://127.0.0.1:1", // never dialed
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if _, _, err := client.Withdraws.WithdrawKRW(ctx, &upbit.WithdrawKRWRequest{Amount: "10000"}); !errors.Is(err, upbit.ErrNotImplemented) {
+		t.Errorf("WithdrawKRW err = %v", err)
+	}
+	if _, err := client.Streams.ConnectPrivate(ctx); !errors.Is(err, upbit.ErrNotImplemented) {
+		t.Errorf("ConnectPrivate err = %v", err)
+	}
+
+	for _, tc := range []struct {
+		region upbit.Region
+		market string
+		price  upbit.Decimal
+		want   upbit.Decimal
+	}{
+		{upbit.RegionKR, "KRW-BTC", "1500", "1"},
+		{upbit.RegionSG, "SGD-BTC", "1500", "1"},
+		{upbit.RegionSG, "SGD-XRP", "0.5", "0.0001"},
+		{upbit.RegionID, "IDR-BTC", "6000000", "1000"},
+		{upbit.RegionKR, "SGD-BTC", "1500", ""},
+	} {
+		if got := tc.region.PriceUnit(tc.market, tc.price); !got.Equal(tc.want) || (got == "") != (tc.want == "") {
+			t.Errorf("%s %s at %s: unit = %q, want %q", tc.region, tc.market, tc.price, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package upbit
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy decides whether a failed request is attempted again. attempt
+// is the number of attempts made so far, starting at 1. resp is nil for
+// network errors.
+//
+// Client.Do only retries GET requests, which are safe to repeat.
+// OrderService.Order retries only orders with an Identifier, see Order.
+type RetryPolicy interface {
+	Retry(attempt int, resp *http.Response, err error) (time.Duration, bool)
+}
+
+// ExponentialBackoff retries network errors, 429 and 5xx responses with an
+// exponentially growing delay with full jitter. Zero fields take defaults of
+// 3 attempts, 100ms and 5s. A MaxBackoff below MinBackoff is raised to it.
+type ExponentialBackoff struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+func (b *ExponentialBackoff) Retry(attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	maxAttempts := b.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	minBackoff := b.MinBackoff
+	if minBackoff <= 0 {
+		minBackoff = 100 * time.Millisecond
+	}
+	maxBackoff := b.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 5 * time.Second
+	}
+	if maxBackoff < minBackoff {
+		maxBackoff = minBackoff
+	}
+
+	if attempt >= maxAttempts || !IsRetryable(err) {
+		return 0, false
+	}
+
+	backoff := minBackoff << uint(attempt-1)
+	if backoff > maxBackoff || backoff < minBackoff {
+		// The shift overflowed or went past the cap.
+		backoff = maxBackoff
+	}
+	if backoff == minBackoff {
+		return minBackoff, true
+	}
+	return minBackoff + time.Duration(rand.Int63n(int64(backoff-minBackoff)+1)), true
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// resign replaces the Authorization header of an already signed request.
+// Upbit rejects a reused nonce, so every attempt needs a fresh token.
+func (c *Client) resign(req *http.Request) error {
+	if req.Header.Get("Authorization") == "" {
+		return nil
+	}
+
+	req.Header.Del("Authorization")
+	return c.generateToken(req, req.URL.RawQuery)
+}
@@ -0,0 +1,144 @@
+package upbit_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+func newRetryClient(t *testing.T, handler http.HandlerFunc) (*upbit.Client, func()) {
+	srv := httptest.NewServer(handler)
+	client, err := upbit.NewClient(nil, &upbit.ClientOptions{
+		AccessKey:       "access",
+		SecretKey:       "secret",
+		ServerURL:       srv.URL,
+		RateLimitPolicy: upbit.RateLimitDisabled,
+		RetryPolicy:     &upbit.ExponentialBackoff{MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, srv.Close
+}
+
+func TestRetryGet(t *testing.T) {
+	var attempts int32
+	tokens := map[string]bool{}
+
+	client, closeServer := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		tokens[r.Header.Get("Authorization")] = true
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[{"currency":"KRW","balance":"1"}]`))
+	})
+	defer closeServer()
+
+	accounts, _, err := client.Accounts.Accounts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 1 || attempts != 3 {
+		t.Errorf("accounts = %v, attempts = %d", accounts, attempts)
+	}
+	if len(tokens) != 3 {
+		t.Errorf("%d distinct tokens for 3 attempts", len(tokens))
+	}
+}
+
+func TestRetryOrder(t *testing.T) {
+	const orderNotFound = `{"error":{"name":"order_not_found","message":"주문을 찾지 못했습니다."}}`
+
+	for _, tc := range []struct {
+		name       string
+		identifier string
+		placed     bool // whether the failed POST reached the matching engine
+		wantPosts  int32
+		wantErr    bool
+	}{
+		{name: "no identifier", wantPosts: 1, wantErr: true},
+		{name: "placed", identifier: "id-1", placed: true, wantPosts: 1},
+		{name: "not placed", identifier: "id-2", wantPosts: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var posts int32
+			client, closeServer := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/v1/orders":
+					if atomic.AddInt32(&posts, 1) == 1 {
+						w.WriteHeader(http.StatusGatewayTimeout)
+						return
+					}
+					w.Write([]byte(`{"uuid":"new","state":"wait"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/v1/order":
+					if r.URL.Query().Get("identifier") != tc.identifier {
+						t.Errorf("lookup by %q", r.URL.Query().Get("identifier"))
+					}
+					if tc.placed {
+						w.Write([]byte(`{"uuid":"placed","state":"wait"}`))
+						return
+					}
+					w.WriteHeader(http.StatusNotFound)
+					w.Write([]byte(orderNotFound))
+				default:
+					http.NotFound(w, r)
+				}
+			})
+			defer closeServer()
+
+			order, _, err := client.Orders.Order(context.Background(), &upbit.OrderRequest{
+				Market:     upbit.KRW_BTC,
+				Side:       upbit.SideBid,
+				Volume:     "1",
+				Price:      "1000",
+				OrdType:    upbit.OrdTypeLimit,
+				Identifier: tc.identifier,
+			})
+			if posts != tc.wantPosts {
+				t.Errorf("posts = %d, want %d", posts, tc.wantPosts)
+			}
+			if tc.wantErr {
+				if err == nil {
+					t.Error("no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := map[bool]string{true: "placed", false: "new"}[tc.placed]; order.UUID != want {
+				t.Errorf("order.UUID = %s, want %s", order.UUID, want)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoffBounds(t *testing.T) {
+	err := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	for _, tc := range []struct {
+		name     string
+		policy   upbit.ExponentialBackoff
+		min, max time.Duration
+	}{
+		{name: "defaults", min: 100 * time.Millisecond, max: 5 * time.Second},
+		{name: "min above default max", policy: upbit.ExponentialBackoff{MaxAttempts: 10, MinBackoff: 10 * time.Second}, min: 10 * time.Second, max: 10 * time.Second},
+		{name: "max below min", policy: upbit.ExponentialBackoff{MaxAttempts: 10, MinBackoff: time.Second, MaxBackoff: time.Millisecond}, min: time.Second, max: time.Second},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for attempt := 1; attempt < 3; attempt++ {
+				d, ok := tc.policy.Retry(attempt, nil, err)
+				if !ok || d < tc.min || d > tc.max {
+					t.Errorf("attempt %d: Retry = %v, %v, want %v..%v", attempt, d, ok, tc.min, tc.max)
+				}
+			}
+		})
+	}
+}
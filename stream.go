@@ -0,0 +1,493 @@
+package upbit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	WebsocketTypeTicker    string = "ticker"
+	WebsocketTypeTrade     string = "trade"
+	WebsocketTypeOrderbook string = "orderbook"
+	WebsocketTypeMyOrder   string = "myOrder" // requires ConnectPrivate
+	WebsocketTypeMyAsset   string = "myAsset" // requires ConnectPrivate
+)
+
+const (
+	StreamSnapshot string = "SNAPSHOT" // stream_type of the first message after subscribing
+	StreamRealtime string = "REALTIME"
+)
+
+var ErrStreamClosed = fmt.Errorf("upbit: stream closed")
+
+const (
+	streamBufferSize = 256
+	streamPingPeriod = 60 * time.Second
+	streamWriteWait  = 10 * time.Second
+)
+
+func parseWebsocketURL(baseURL *url.URL, websocketURL string) (*url.URL, error) {
+	if websocketURL != "" {
+		return url.Parse(websocketURL)
+	}
+
+	u := *baseURL
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	case "http":
+		u.Scheme = "ws"
+	}
+
+	return u.Parse("websocket/v1")
+}
+
+// Stream is a single WebSocket connection to Upbit. Events are delivered on
+// typed channels which are closed when the connection ends. Upbit replaces the
+// whole subscription set on every request frame, so Stream keeps the current
+// set and re-sends it on Subscribe and Unsubscribe.
+type Stream struct {
+	conn    *websocket.Conn
+	ticket  string
+	writeMu sync.Mutex
+
+	mu   sync.Mutex
+	subs []WebsocketRequestType
+	err  error
+
+	tickers    chan *Ticker
+	trades     chan *Trade
+	orderbooks chan *Orderbook
+	myOrders   chan *MyOrderEvent
+	myAssets   chan *MyAssetEvent
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Connect dials the quotation WebSocket and subscribes to types, if any.
+func (s *StreamService) Connect(ctx context.Context, types ...WebsocketRequestType) (*Stream, error) {
+	return s.connect(ctx, s.client.websocketURL.String(), nil, types)
+}
+
+// ConnectPrivate dials the private WebSocket endpoint authenticated with the
+// client's access key. myOrder and myAsset types are only served there.
+func (s *StreamService) ConnectPrivate(ctx context.Context, types ...WebsocketRequestType) (*Stream, error) {
+	if err := s.client.supports(featurePrivateStream); err != nil {
+		return nil, err
+	}
+
+	u := *s.client.websocketURL
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/private"
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.client.generateToken(req, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.connect(ctx, u.String(), req.Header, types)
+}
+
+func (s *StreamService) connect(ctx context.Context, u string, header http.Header, types []WebsocketRequestType) (*Stream, error) {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
+	if s.client.debug {
+		log.Println("websocket", u)
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, u, header)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &Stream{
+		conn:       conn,
+		ticket:     uuid.New().String(),
+		tickers:    make(chan *Ticker, streamBufferSize),
+		trades:     make(chan *Trade, streamBufferSize),
+		orderbooks: make(chan *Orderbook, streamBufferSize),
+		myOrders:   make(chan *MyOrderEvent, streamBufferSize),
+		myAssets:   make(chan *MyAssetEvent, streamBufferSize),
+		done:       make(chan struct{}),
+	}
+
+	go st.readLoop()
+	go st.pingLoop()
+
+	if len(types) > 0 {
+		if err := st.Subscribe(types...); err != nil {
+			st.Close()
+			return nil, err
+		}
+	}
+
+	return st, nil
+}
+
+func (st *Stream) Ticker() <-chan *Ticker {
+	return st.tickers
+}
+
+func (st *Stream) Trade() <-chan *Trade {
+	return st.trades
+}
+
+func (st *Stream) Orderbook() <-chan *Orderbook {
+	return st.orderbooks
+}
+
+func (st *Stream) MyOrder() <-chan *MyOrderEvent {
+	return st.myOrders
+}
+
+func (st *Stream) MyAsset() <-chan *MyAssetEvent {
+	return st.myAssets
+}
+
+// Done is closed when the connection ends.
+func (st *Stream) Done() <-chan struct{} {
+	return st.done
+}
+
+// Err returns the error that ended the stream, or nil if it was closed by
+// Close or is still running.
+func (st *Stream) Err() error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.err
+}
+
+// Subscriptions returns a copy of the current subscription set.
+func (st *Stream) Subscriptions() []WebsocketRequestType {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return copyRequestTypes(st.subs)
+}
+
+// Subscribe adds types to the subscription set. Codes of an already
+// subscribed type are merged.
+func (st *Stream) Subscribe(types ...WebsocketRequestType) error {
+	st.mu.Lock()
+	for _, typ := range types {
+		st.subs = mergeRequestType(st.subs, typ)
+	}
+	req := st.request()
+	st.mu.Unlock()
+
+	return st.send(req)
+}
+
+// Unsubscribe removes codes of typ from the subscription set, or the whole
+// type if no code is given. A market removes its codes of any orderbook
+// level, so KRW-BTC removes KRW-BTC.5. Events that are no longer subscribed
+// are dropped even if the server keeps sending them.
+func (st *Stream) Unsubscribe(typ string, codes ...string) error {
+	st.mu.Lock()
+	st.subs = removeRequestType(st.subs, typ, codes)
+	req := st.request()
+	st.mu.Unlock()
+
+	if len(req.Type) == 0 {
+		return nil
+	}
+	return st.send(req)
+}
+
+// setSubscriptions replaces the subscription set with subs.
+func (st *Stream) setSubscriptions(subs []WebsocketRequestType) error {
+	st.mu.Lock()
+	st.subs = copyRequestTypes(subs)
+	req := st.request()
+	st.mu.Unlock()
+
+	if len(req.Type) == 0 {
+		return nil
+	}
+	return st.send(req)
+}
+
+func (st *Stream) Close() error {
+	st.writeMu.Lock()
+	st.conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(streamWriteWait))
+	st.writeMu.Unlock()
+
+	st.shutdown(nil)
+	return nil
+}
+
+func (st *Stream) shutdown(err error) {
+	st.closeOnce.Do(func() {
+		st.mu.Lock()
+		st.err = err
+		st.mu.Unlock()
+
+		close(st.done)
+		st.conn.Close()
+	})
+}
+
+func (st *Stream) request() *WebsocketRequest {
+	return &WebsocketRequest{
+		Ticket: st.ticket,
+		Type:   copyRequestTypes(st.subs),
+	}
+}
+
+func (st *Stream) send(req *WebsocketRequest) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	st.writeMu.Lock()
+	defer st.writeMu.Unlock()
+
+	select {
+	case <-st.done:
+		return ErrStreamClosed
+	default:
+	}
+
+	st.conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+	return st.conn.WriteMessage(websocket.TextMessage, b)
+}
+
+func (st *Stream) subscribed(typ, code string) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for _, sub := range st.subs {
+		if sub.Type != typ {
+			continue
+		}
+		if len(sub.Codes) == 0 {
+			return true
+		}
+		for _, c := range sub.Codes {
+			if streamCode(c) == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// streamCode returns the market of a subscribed code. Orderbook codes may
+// carry the number of levels, as in "KRW-BTC.5", which events leave out.
+func streamCode(code string) string {
+	if i := strings.LastIndex(code, "."); i > 0 {
+		return code[:i]
+	}
+	return code
+}
+
+func (st *Stream) pingLoop() {
+	ticker := time.NewTicker(streamPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-st.done:
+			return
+		case <-ticker.C:
+			err := st.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteWait))
+			if err != nil {
+				st.shutdown(err)
+				return
+			}
+		}
+	}
+}
+
+func (st *Stream) readLoop() {
+	defer func() {
+		close(st.tickers)
+		close(st.trades)
+		close(st.orderbooks)
+		close(st.myOrders)
+		close(st.myAssets)
+	}()
+
+	for {
+		_, msg, err := st.conn.ReadMessage()
+		if err != nil {
+			st.shutdown(err)
+			return
+		}
+
+		if err := st.dispatch(msg); err != nil {
+			st.shutdown(err)
+			return
+		}
+
+		select {
+		case <-st.done:
+			return
+		default:
+		}
+	}
+}
+
+type streamMessageHeader struct {
+	Type string `json:"type"`
+	Code string `json:"code"`
+}
+
+func (st *Stream) dispatch(msg []byte) error {
+	errResp := &ErrResponse{}
+	if err := json.Unmarshal(msg, errResp); err == nil && errResp.Detail.Name != "" {
+		return errResp
+	}
+
+	head := streamMessageHeader{}
+	if err := json.Unmarshal(msg, &head); err != nil {
+		return err
+	}
+
+	// {"status":"UP"} answers a PING text frame.
+	if head.Type == "" || !st.subscribed(head.Type, head.Code) {
+		return nil
+	}
+
+	switch head.Type {
+	case WebsocketTypeTicker:
+		ticker := &Ticker{}
+		if err := json.Unmarshal(msg, ticker); err != nil {
+			return err
+		}
+		ticker.Market = ticker.Code
+		select {
+		case st.tickers <- ticker:
+		case <-st.done:
+		}
+	case WebsocketTypeTrade:
+		trade := &Trade{}
+		if err := json.Unmarshal(msg, trade); err != nil {
+			return err
+		}
+		select {
+		case st.trades <- trade:
+		case <-st.done:
+		}
+	case WebsocketTypeOrderbook:
+		orderbook := &Orderbook{}
+		if err := json.Unmarshal(msg, orderbook); err != nil {
+			return err
+		}
+		orderbook.Market = orderbook.Code
+		select {
+		case st.orderbooks <- orderbook:
+		case <-st.done:
+		}
+	case WebsocketTypeMyOrder:
+		myOrder := &MyOrderEvent{}
+		if err := json.Unmarshal(msg, myOrder); err != nil {
+			return err
+		}
+		select {
+		case st.myOrders <- myOrder:
+		case <-st.done:
+		}
+	case WebsocketTypeMyAsset:
+		myAsset := &MyAssetEvent{}
+		if err := json.Unmarshal(msg, myAsset); err != nil {
+			return err
+		}
+		select {
+		case st.myAssets <- myAsset:
+		case <-st.done:
+		}
+	}
+
+	return nil
+}
+
+func mergeRequestType(subs []WebsocketRequestType, typ WebsocketRequestType) []WebsocketRequestType {
+	for i, sub := range subs {
+		if sub.Type != typ.Type {
+			continue
+		}
+
+		for _, code := range typ.Codes {
+			if !containsString(sub.Codes, code) {
+				sub.Codes = append(sub.Codes, code)
+			}
+		}
+		sub.IsOnlySnapShot = typ.IsOnlySnapShot
+		sub.IsOnlyRealtime = typ.IsOnlyRealtime
+		subs[i] = sub
+		return subs
+	}
+
+	typ.Codes = append([]string(nil), typ.Codes...)
+	return append(subs, typ)
+}
+
+func removeRequestType(subs []WebsocketRequestType, typ string, codes []string) []WebsocketRequestType {
+	result := subs[:0]
+	for _, sub := range subs {
+		if sub.Type == typ {
+			if len(codes) == 0 {
+				continue
+			}
+
+			remaining := []string{}
+			for _, c := range sub.Codes {
+				removed := false
+				for _, code := range codes {
+					if streamCode(code) == streamCode(c) {
+						removed = true
+						break
+					}
+				}
+				if !removed {
+					remaining = append(remaining, c)
+				}
+			}
+			if len(remaining) == 0 {
+				continue
+			}
+			sub.Codes = remaining
+		}
+		result = append(result, sub)
+	}
+	return result
+}
+
+func copyRequestTypes(subs []WebsocketRequestType) []WebsocketRequestType {
+	result := make([]WebsocketRequestType, len(subs))
+	for i, sub := range subs {
+		sub.Codes = append([]string(nil), sub.Codes...)
+		result[i] = sub
+	}
+	return result
+}
+
+func containsString(lst []string, s string) bool {
+	for _, v := range lst {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
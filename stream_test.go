@@ -0,0 +1,431 @@
+package upbit_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gorilla/websocket"
+	"github.com/investing-kr/go-upbit"
+)
+
+func newStreamServer(t *testing.T, handle func(conn *websocket.Conn, r *http.Request), rest http.Handler) (*upbit.Client, func()) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/websocket/v1") {
+			if rest == nil {
+				http.NotFound(w, r)
+				return
+			}
+			rest.ServeHTTP(w, r)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		handle(conn, r)
+	}))
+
+	client, err := upbit.NewClient(nil, &upbit.ClientOptions{
+		AccessKey: "access",
+		SecretKey: "secret",
+		ServerURL: srv.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, srv.Close
+}
+
+func TestStream(t *testing.T) {
+	requests := make(chan []map[string]interface{}, 4)
+
+	client, closeServer := newStreamServer(t, func(conn *websocket.Conn, r *http.Request) {
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			var req []map[string]interface{}
+			if err := json.Unmarshal(msg, &req); err != nil {
+				t.Error(err)
+				return
+			}
+			requests <- req
+
+			for _, m := range []string{
+				`{"type":"ticker","code":"KRW-BTC","trade_price":100.5,"trade_timestamp":1,"stream_type":"SNAPSHOT"}`,
+				`{"type":"ticker","code":"KRW-XRP","trade_price":1}`,
+				`{"type":"trade","code":"KRW-BTC","trade_price":100.5,"trade_volume":0.1,"ask_bid":"BID","sequential_id":42}`,
+				`{"type":"orderbook","code":"KRW-BTC","orderbook_units":[{"ask_price":101,"bid_price":100,"ask_size":1,"bid_size":2}]}`,
+			} {
+				if err := conn.WriteMessage(websocket.BinaryMessage, []byte(m)); err != nil {
+					return
+				}
+			}
+		}
+	}, nil)
+	defer closeServer()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	st, err := client.Streams.Connect(ctx,
+		upbit.WebsocketRequestType{Type: upbit.WebsocketTypeTicker, Codes: []string{upbit.KRW_BTC}},
+		upbit.WebsocketRequestType{Type: upbit.WebsocketTypeTrade, Codes: []string{upbit.KRW_BTC}},
+		upbit.WebsocketRequestType{Type: upbit.WebsocketTypeOrderbook, Codes: []string{upbit.KRW_BTC}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	req := <-requests
+	if len(req) != 4 || req[0]["ticket"] == nil || req[1]["type"] != "ticker" {
+		t.Fatalf("unexpected request frame: %v", req)
+	}
+
+	select {
+	case ticker := <-st.Ticker():
+		if ticker.Market != upbit.KRW_BTC || ticker.TradePrice != "100.5" || ticker.StreamType != upbit.StreamSnapshot {
+			t.Errorf("unexpected ticker: %+v", ticker)
+		}
+	case <-ctx.Done():
+		t.Fatal("no ticker")
+	}
+
+	select {
+	case trade := <-st.Trade():
+		if trade.SequentialID != 42 || trade.AskBid != "BID" {
+			t.Errorf("unexpected trade: %+v", trade)
+		}
+	case <-ctx.Done():
+		t.Fatal("no trade")
+	}
+
+	select {
+	case ob := <-st.Orderbook():
+		if ob.Market != upbit.KRW_BTC || len(ob.OrderbookUnits) != 1 || ob.OrderbookUnits[0].BidSize != "2" {
+			t.Errorf("unexpected orderbook: %+v", ob)
+		}
+	case <-ctx.Done():
+		t.Fatal("no orderbook")
+	}
+
+	// KRW-XRP was not subscribed and must have been dropped.
+	select {
+	case ticker := <-st.Ticker():
+		t.Errorf("unexpected ticker: %+v", ticker)
+	default:
+	}
+
+	if err := st.Unsubscribe(upbit.WebsocketTypeTrade); err != nil {
+		t.Fatal(err)
+	}
+
+	req = <-requests
+	if len(req) != 3 {
+		t.Fatalf("unexpected request frame after unsubscribe: %v", req)
+	}
+
+	st.Close()
+	for range st.Ticker() {
+	}
+	if err := st.Err(); err != nil {
+		t.Errorf("Err() = %v after Close", err)
+	}
+}
+
+func TestStreamOrderbookLevels(t *testing.T) {
+	requests := make(chan []map[string]interface{}, 2)
+
+	client, closeServer := newStreamServer(t, func(conn *websocket.Conn, r *http.Request) {
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req []map[string]interface{}
+			if err := json.Unmarshal(msg, &req); err != nil {
+				t.Error(err)
+				return
+			}
+			requests <- req
+
+			conn.WriteMessage(websocket.BinaryMessage, []byte(`{"type":"orderbook","code":"KRW-BTC","orderbook_units":[{"ask_price":101,"bid_price":100}]}`))
+		}
+	}, nil)
+	defer closeServer()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	st, err := client.Streams.Connect(ctx,
+		upbit.WebsocketRequestType{Type: upbit.WebsocketTypeOrderbook, Codes: []string{upbit.KRW_BTC + ".5"}},
+		upbit.WebsocketRequestType{Type: upbit.WebsocketTypeTicker, Codes: []string{upbit.KRW_BTC}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	select {
+	case ob := <-st.Orderbook():
+		if ob.Market != upbit.KRW_BTC {
+			t.Errorf("unexpected orderbook: %+v", ob)
+		}
+	case <-ctx.Done():
+		t.Fatal("orderbook of a KRW-BTC.5 subscription was dropped")
+	}
+
+	// The market alone unsubscribes the level.
+	<-requests
+	if err := st.Unsubscribe(upbit.WebsocketTypeOrderbook, upbit.KRW_BTC); err != nil {
+		t.Fatal(err)
+	}
+	if req := <-requests; len(req) != 2 || req[1]["type"] != upbit.WebsocketTypeTicker {
+		t.Errorf("request frame after unsubscribe = %v", req)
+	}
+}
+
+func TestStreamPrivate(t *testing.T) {
+	client, closeServer := newStreamServer(t, func(conn *websocket.Conn, r *http.Request) {
+		if r.URL.Path != "/websocket/v1/private" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+			return
+		}
+
+		auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		token, err := jwt.Parse(auth, func(token *jwt.Token) (interface{}, error) {
+			return []byte("secret"), nil
+		})
+		if err != nil || !token.Valid {
+			t.Errorf("invalid token: %v", err)
+			return
+		}
+		if claims := token.Claims.(jwt.MapClaims); claims["access_key"] != "access" {
+			t.Errorf("unexpected claims: %v", claims)
+		}
+
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+		for _, m := range []string{
+			`{"type":"myOrder","code":"KRW-BTC","uuid":"u1","ask_bid":"BID","order_type":"limit","state":"trade","price":100,"volume":2,"remaining_volume":1,"executed_volume":1,"trades_count":1,"order_timestamp":1700000000000}`,
+			`{"type":"myAsset","asset_uuid":"a1","assets":[{"currency":"KRW","balance":1000.5,"locked":100}]}`,
+		} {
+			if err := conn.WriteMessage(websocket.BinaryMessage, []byte(m)); err != nil {
+				return
+			}
+		}
+		conn.ReadMessage()
+	}, nil)
+	defer closeServer()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	st, err := client.Streams.ConnectPrivate(ctx,
+		upbit.WebsocketRequestType{Type: upbit.WebsocketTypeMyOrder},
+		upbit.WebsocketRequestType{Type: upbit.WebsocketTypeMyAsset},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	select {
+	case ev := <-st.MyOrder():
+		order := ev.Order()
+		if order.UUID != "u1" || order.Side != upbit.SideBid || order.State != upbit.OrderStateWait ||
+			order.Price != "100" || order.RemainingVolume != "1" || order.Market != upbit.KRW_BTC {
+			t.Errorf("unexpected order: %+v", order)
+		}
+	case <-ctx.Done():
+		t.Fatal("no myOrder")
+	}
+
+	select {
+	case ev := <-st.MyAsset():
+		accounts := ev.Accounts()
+		if len(accounts) != 1 || accounts[0].Currency != "KRW" || accounts[0].Balance != "1000.5" || accounts[0].Locked != "100" {
+			t.Errorf("unexpected accounts: %+v", accounts)
+		}
+	case <-ctx.Done():
+		t.Fatal("no myAsset")
+	}
+}
+
+func TestSupervisor(t *testing.T) {
+	var connections int32
+
+	rest := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/ticker":
+			w.Write([]byte(`[{"market":"KRW-BTC","trade_price":150,"trade_timestamp":150}]`))
+		case "/v1/trades/ticks":
+			w.Write([]byte(`[{"market":"KRW-BTC","trade_price":200,"timestamp":200,"sequential_id":2},` +
+				`{"market":"KRW-BTC","trade_price":100,"timestamp":100,"sequential_id":1}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	client, closeServer := newStreamServer(t, func(conn *websocket.Conn, r *http.Request) {
+		n := atomic.AddInt32(&connections, 1)
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+
+		var msgs []string
+		switch n {
+		case 1:
+			msgs = []string{
+				`{"type":"ticker","code":"KRW-BTC","trade_price":100,"trade_timestamp":100}`,
+				`{"type":"trade","code":"KRW-BTC","trade_price":100,"trade_timestamp":100,"sequential_id":1}`,
+			}
+		default:
+			msgs = []string{
+				`{"type":"ticker","code":"KRW-BTC","trade_price":100,"trade_timestamp":100}`,
+				`{"type":"ticker","code":"KRW-BTC","trade_price":200,"trade_timestamp":200}`,
+				`{"type":"trade","code":"KRW-BTC","trade_price":100,"sequential_id":1}`,
+				`{"type":"trade","code":"KRW-BTC","trade_price":200,"sequential_id":2}`,
+				`{"type":"trade","code":"KRW-BTC","trade_price":300,"sequential_id":3}`,
+			}
+		}
+		for _, m := range msgs {
+			if err := conn.WriteMessage(websocket.BinaryMessage, []byte(m)); err != nil {
+				return
+			}
+		}
+
+		if n == 1 {
+			// Drop the first connection abruptly.
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}, rest)
+	defer closeServer()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sv := client.Streams.Supervise(ctx, &upbit.SupervisorOptions{MinBackoff: 10 * time.Millisecond},
+		upbit.WebsocketRequestType{Type: upbit.WebsocketTypeTicker, Codes: []string{upbit.KRW_BTC}},
+		upbit.WebsocketRequestType{Type: upbit.WebsocketTypeTrade, Codes: []string{upbit.KRW_BTC}},
+	)
+	defer sv.Close()
+
+	var tickers []upbit.Decimal
+	for len(tickers) < 3 {
+		select {
+		case ticker := <-sv.Ticker():
+			tickers = append(tickers, ticker.TradePrice)
+		case <-ctx.Done():
+			t.Fatalf("tickers = %v", tickers)
+		}
+	}
+	if tickers[0] != "100" || tickers[1] != "150" || tickers[2] != "200" {
+		t.Errorf("tickers = %v, want [100 150 200]", tickers)
+	}
+
+	var trades []int64
+	for len(trades) < 3 {
+		select {
+		case trade := <-sv.Trade():
+			trades = append(trades, trade.SequentialID)
+		case <-ctx.Done():
+			t.Fatalf("trades = %v", trades)
+		}
+	}
+	if trades[0] != 1 || trades[1] != 2 || trades[2] != 3 {
+		t.Errorf("trades = %v, want [1 2 3]", trades)
+	}
+
+	if _, ok := (<-sv.Status()).(*upbit.Disconnected); !ok {
+		t.Error("first status is not Disconnected")
+	}
+	reconnected, ok := (<-sv.Status()).(*upbit.Reconnected)
+	if !ok {
+		t.Fatal("second status is not Reconnected")
+	}
+	if len(reconnected.Gaps) != 2 || reconnected.Gaps[1].Backfilled != 1 {
+		t.Errorf("gaps = %+v", reconnected.Gaps)
+	}
+}
+
+func TestSupervisorSubscribeWhileConnecting(t *testing.T) {
+	arrived := make(chan struct{})
+	release := make(chan struct{})
+	requests := make(chan []map[string]interface{}, 4)
+
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Hold the connection open until the test subscribed.
+		arrived <- struct{}{}
+		<-release
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req []map[string]interface{}
+			if err := json.Unmarshal(msg, &req); err != nil {
+				t.Error(err)
+				return
+			}
+			requests <- req
+		}
+	}))
+	defer srv.Close()
+
+	client, err := upbit.NewClient(nil, &upbit.ClientOptions{ServerURL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sv := client.Streams.Supervise(ctx, nil,
+		upbit.WebsocketRequestType{Type: upbit.WebsocketTypeTicker, Codes: []string{upbit.KRW_BTC}},
+	)
+	defer sv.Close()
+
+	<-arrived
+	if err := sv.Subscribe(upbit.WebsocketRequestType{Type: upbit.WebsocketTypeTrade, Codes: []string{upbit.KRW_BTC}}); err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+
+	var req []map[string]interface{}
+	for len(req) != 3 {
+		select {
+		case req = <-requests:
+		case <-ctx.Done():
+			t.Fatalf("last request frame = %v, want the trade subscription", req)
+		}
+	}
+	if req[1]["type"] != "ticker" || req[2]["type"] != "trade" {
+		t.Errorf("request frame = %v", req)
+	}
+}
@@ -0,0 +1,445 @@
+package upbit
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"sync"
+	"time"
+)
+
+type SupervisorOptions struct {
+	// Private connects with ConnectPrivate, required for myOrder and myAsset.
+	Private bool
+	// MinBackoff and MaxBackoff bound the exponential reconnect delay.
+	// Defaults are 500ms and 30s.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// DisableBackfill turns off the REST requests made after a reconnect to
+	// fill the events missed while disconnected.
+	DisableBackfill bool
+}
+
+// StreamStatus is either *Disconnected or *Reconnected.
+type StreamStatus interface {
+	isStreamStatus()
+}
+
+// Disconnected is emitted when the underlying connection is lost.
+type Disconnected struct {
+	Err error
+	At  time.Time
+}
+
+// Reconnected is emitted once the subscription set has been re-sent on a new
+// connection and missed events have been backfilled.
+type Reconnected struct {
+	Attempts int
+	Downtime time.Duration
+	Gaps     []*StreamGap
+}
+
+// StreamGap describes events of a code that may have been missed while
+// disconnected. Backfilled is the number of events recovered through REST;
+// Err is why recovering stopped short of the gap, if it did.
+type StreamGap struct {
+	Type             string
+	Code             string
+	LastTimestamp    int64
+	LastSequentialID int64
+	Backfilled       int
+	Err              error
+}
+
+func (*Disconnected) isStreamStatus() {}
+func (*Reconnected) isStreamStatus()  {}
+
+// Supervisor keeps a Stream alive. It reconnects with exponential backoff,
+// re-sends the last subscription set and, on reconnect, backfills tickers and
+// trades through the REST quotation API. Events already delivered are not
+// repeated.
+type Supervisor struct {
+	service *StreamService
+	opts    SupervisorOptions
+
+	mu     sync.Mutex
+	subs   []WebsocketRequestType
+	stream *Stream
+
+	lastTicker map[string]int64 // trade timestamp by code
+	lastTrade  map[string]*Trade
+
+	tickers    chan *Ticker
+	trades     chan *Trade
+	orderbooks chan *Orderbook
+	myOrders   chan *MyOrderEvent
+	myAssets   chan *MyAssetEvent
+	status     chan StreamStatus
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Supervise starts a Supervisor subscribed to types. It runs until ctx is done
+// or Close is called.
+func (s *StreamService) Supervise(ctx context.Context, opts *SupervisorOptions, types ...WebsocketRequestType) *Supervisor {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
+	sv := &Supervisor{
+		service:    s,
+		lastTicker: map[string]int64{},
+		lastTrade:  map[string]*Trade{},
+		tickers:    make(chan *Ticker, streamBufferSize),
+		trades:     make(chan *Trade, streamBufferSize),
+		orderbooks: make(chan *Orderbook, streamBufferSize),
+		myOrders:   make(chan *MyOrderEvent, streamBufferSize),
+		myAssets:   make(chan *MyAssetEvent, streamBufferSize),
+		status:     make(chan StreamStatus, streamBufferSize),
+		done:       make(chan struct{}),
+	}
+	if opts != nil {
+		sv.opts = *opts
+	}
+	if sv.opts.MinBackoff <= 0 {
+		sv.opts.MinBackoff = 500 * time.Millisecond
+	}
+	if sv.opts.MaxBackoff < sv.opts.MinBackoff {
+		sv.opts.MaxBackoff = 30 * time.Second
+	}
+	for _, typ := range types {
+		sv.subs = mergeRequestType(sv.subs, typ)
+	}
+
+	ctx, sv.cancel = context.WithCancel(ctx)
+	go sv.run(ctx)
+
+	return sv
+}
+
+func (sv *Supervisor) Ticker() <-chan *Ticker {
+	return sv.tickers
+}
+
+func (sv *Supervisor) Trade() <-chan *Trade {
+	return sv.trades
+}
+
+func (sv *Supervisor) Orderbook() <-chan *Orderbook {
+	return sv.orderbooks
+}
+
+func (sv *Supervisor) MyOrder() <-chan *MyOrderEvent {
+	return sv.myOrders
+}
+
+func (sv *Supervisor) MyAsset() <-chan *MyAssetEvent {
+	return sv.myAssets
+}
+
+// Status delivers *Disconnected and *Reconnected events. Events are dropped
+// if the channel buffer is full.
+func (sv *Supervisor) Status() <-chan StreamStatus {
+	return sv.status
+}
+
+// Done is closed after the supervisor stopped and all channels are closed.
+func (sv *Supervisor) Done() <-chan struct{} {
+	return sv.done
+}
+
+func (sv *Supervisor) Close() error {
+	sv.cancel()
+	<-sv.done
+	return nil
+}
+
+// Subscribe adds types to the subscription set, which survives reconnects.
+func (sv *Supervisor) Subscribe(types ...WebsocketRequestType) error {
+	sv.mu.Lock()
+	defer sv.mu.Unlock()
+
+	for _, typ := range types {
+		sv.subs = mergeRequestType(sv.subs, typ)
+	}
+	if sv.stream == nil {
+		return nil
+	}
+	return sv.stream.Subscribe(types...)
+}
+
+func (sv *Supervisor) Unsubscribe(typ string, codes ...string) error {
+	sv.mu.Lock()
+	defer sv.mu.Unlock()
+
+	sv.subs = removeRequestType(sv.subs, typ, codes)
+	if sv.stream == nil {
+		return nil
+	}
+	return sv.stream.Unsubscribe(typ, codes...)
+}
+
+func (sv *Supervisor) subscriptions() []WebsocketRequestType {
+	sv.mu.Lock()
+	defer sv.mu.Unlock()
+
+	return copyRequestTypes(sv.subs)
+}
+
+func (sv *Supervisor) connect(ctx context.Context) (*Stream, error) {
+	subs := sv.subscriptions()
+	if sv.opts.Private {
+		return sv.service.ConnectPrivate(ctx, subs...)
+	}
+	return sv.service.Connect(ctx, subs...)
+}
+
+// publish makes st the current stream. Subscribe and Unsubscribe calls made
+// while st was connecting only changed sv.subs, so st is brought up to date.
+func (sv *Supervisor) publish(st *Stream) {
+	sv.mu.Lock()
+	defer sv.mu.Unlock()
+
+	sv.stream = st
+	if !reflect.DeepEqual(st.Subscriptions(), copyRequestTypes(sv.subs)) {
+		// A failed send ends the stream, which pump notices.
+		st.setSubscriptions(sv.subs)
+	}
+}
+
+func (sv *Supervisor) run(ctx context.Context) {
+	defer func() {
+		close(sv.tickers)
+		close(sv.trades)
+		close(sv.orderbooks)
+		close(sv.myOrders)
+		close(sv.myAssets)
+		close(sv.status)
+		close(sv.done)
+	}()
+
+	var (
+		attempts     int
+		disconnected time.Time
+	)
+
+	for {
+		st, err := sv.connect(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if !sv.sleep(ctx, attempts) {
+				return
+			}
+			attempts++
+			continue
+		}
+
+		sv.publish(st)
+
+		if !disconnected.IsZero() {
+			gaps := sv.backfill(ctx)
+			sv.emitStatus(&Reconnected{
+				Attempts: attempts + 1,
+				Downtime: time.Since(disconnected),
+				Gaps:     gaps,
+			})
+		}
+		attempts = 0
+
+		sv.pump(ctx, st)
+		st.Close()
+
+		sv.mu.Lock()
+		sv.stream = nil
+		sv.mu.Unlock()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		disconnected = time.Now()
+		sv.emitStatus(&Disconnected{Err: st.Err(), At: disconnected})
+	}
+}
+
+func (sv *Supervisor) sleep(ctx context.Context, attempts int) bool {
+	backoff := sv.opts.MinBackoff << uint(attempts)
+	if backoff > sv.opts.MaxBackoff || backoff <= 0 {
+		backoff = sv.opts.MaxBackoff
+	}
+	backoff = backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (sv *Supervisor) pump(ctx context.Context, st *Stream) {
+	tickers, trades, orderbooks, myOrders, myAssets := st.Ticker(), st.Trade(), st.Orderbook(), st.MyOrder(), st.MyAsset()
+
+	// Every channel of the stream is closed when it ends, so keep reading
+	// until all of them are drained.
+	for tickers != nil || trades != nil || orderbooks != nil || myOrders != nil || myAssets != nil {
+		select {
+		case <-ctx.Done():
+			return
+		case ticker, ok := <-tickers:
+			if !ok {
+				tickers = nil
+				continue
+			}
+			sv.onTicker(ctx, ticker)
+		case trade, ok := <-trades:
+			if !ok {
+				trades = nil
+				continue
+			}
+			sv.onTrade(ctx, trade)
+		case orderbook, ok := <-orderbooks:
+			if !ok {
+				orderbooks = nil
+				continue
+			}
+			select {
+			case sv.orderbooks <- orderbook:
+			case <-ctx.Done():
+			}
+		case myOrder, ok := <-myOrders:
+			if !ok {
+				myOrders = nil
+				continue
+			}
+			select {
+			case sv.myOrders <- myOrder:
+			case <-ctx.Done():
+			}
+		case myAsset, ok := <-myAssets:
+			if !ok {
+				myAssets = nil
+				continue
+			}
+			select {
+			case sv.myAssets <- myAsset:
+			case <-ctx.Done():
+			}
+		}
+	}
+}
+
+func (sv *Supervisor) onTicker(ctx context.Context, ticker *Ticker) {
+	if last, ok := sv.lastTicker[ticker.Market]; ok && ticker.TradeTimestamp < last {
+		return
+	}
+	sv.lastTicker[ticker.Market] = ticker.TradeTimestamp
+
+	select {
+	case sv.tickers <- ticker:
+	case <-ctx.Done():
+	}
+}
+
+func (sv *Supervisor) onTrade(ctx context.Context, trade *Trade) {
+	if last, ok := sv.lastTrade[trade.Code]; ok && trade.SequentialID <= last.SequentialID {
+		return
+	}
+	sv.lastTrade[trade.Code] = trade
+
+	select {
+	case sv.trades <- trade:
+	case <-ctx.Done():
+	}
+}
+
+func (sv *Supervisor) backfill(ctx context.Context) []*StreamGap {
+	gaps := []*StreamGap{}
+
+	for _, sub := range sv.subscriptions() {
+		switch sub.Type {
+		case WebsocketTypeTicker:
+			for _, code := range sub.Codes {
+				last, ok := sv.lastTicker[code]
+				if !ok {
+					continue
+				}
+				gap := &StreamGap{Type: sub.Type, Code: code, LastTimestamp: last}
+				gaps = append(gaps, gap)
+
+				if sv.opts.DisableBackfill {
+					continue
+				}
+				ticker, _, err := sv.service.client.Candles.TickerMarket(ctx, code)
+				if err != nil || ticker.TradeTimestamp <= last {
+					continue
+				}
+				sv.onTicker(ctx, ticker)
+				gap.Backfilled++
+			}
+		case WebsocketTypeTrade:
+			for _, code := range sub.Codes {
+				last, ok := sv.lastTrade[code]
+				if !ok {
+					continue
+				}
+				gap := &StreamGap{
+					Type:             sub.Type,
+					Code:             code,
+					LastTimestamp:    last.TradeTimestamp,
+					LastSequentialID: last.SequentialID,
+				}
+				gaps = append(gaps, gap)
+
+				if sv.opts.DisableBackfill {
+					continue
+				}
+				missed, err := sv.missedTrades(ctx, last)
+				for _, trade := range missed {
+					sv.onTrade(ctx, trade)
+					gap.Backfilled++
+				}
+				gap.Err = err
+			}
+		}
+	}
+
+	return gaps
+}
+
+// missedTrades returns trades after last in chronological order, fetched from
+// trades/ticks. Trades are fetched only as far back as last.TradeTimestamp;
+// on error, the trades fetched until then are returned with it.
+func (sv *Supervisor) missedTrades(ctx context.Context, last *Trade) ([]*Trade, error) {
+	since := time.Unix(0, last.TradeTimestamp*int64(time.Millisecond))
+	it := sv.service.client.Quotations.TicksIter(last.Code, nil, since)
+
+	missed := []*Trade{}
+	for it.Next(ctx) {
+		tick := it.Tick()
+		if tick.SequentialID <= last.SequentialID {
+			break
+		}
+		missed = append(missed, tick.Trade())
+	}
+
+	for i, j := 0, len(missed)-1; i < j; i, j = i+1, j-1 {
+		missed[i], missed[j] = missed[j], missed[i]
+	}
+	return missed, it.Err()
+}
+
+// emitStatus never blocks so that consumers which ignore Status keep
+// receiving market events.
+func (sv *Supervisor) emitStatus(status StreamStatus) {
+	select {
+	case sv.status <- status:
+	default:
+	}
+}
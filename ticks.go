@@ -0,0 +1,152 @@
+package upbit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+// maxTicksCount is the largest count accepted by trades/ticks.
+const maxTicksCount = 500
+
+// maxTicksDaysAgo is the oldest day trades/ticks serves.
+const maxTicksDaysAgo = 7
+
+// ErrTicksTooOld is returned by TicksIter when since is before the oldest
+// day trades/ticks serves.
+var ErrTicksTooOld = fmt.Errorf("upbit: trades before %d days ago are not served", maxTicksDaysAgo)
+
+type TickListOptions struct {
+	To      string `url:"to,omitempty"` // HHmmss or HH:mm:ss, UTC
+	Count   int    `url:"count,omitempty"`
+	Cursor  int64  `url:"cursor,omitempty"` // sequential_id to page from
+	DaysAgo int    `url:"daysAgo,omitempty"`
+}
+
+// Ticks returns recent trades of market, newest first.
+func (s *QuotationService) Ticks(ctx context.Context, market string, opts *TickListOptions) ([]*TradeTick, *http.Response, error) {
+	qv, err := query.Values(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	qv.Add("market", market)
+
+	u := fmt.Sprintf("v1/trades/ticks?%s", qv.Encode())
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ticks []*TradeTick
+
+	resp, err := s.client.Do(ctx, req, &ticks)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ticks, resp, nil
+}
+
+// TicksIter walks trades/ticks backwards following the sequential_id cursor,
+// and on to the previous UTC day with daysAgo until since is reached.
+//
+//	it := client.Quotations.TicksIter(upbit.KRW_BTC, nil, time.Now().Add(-24*time.Hour))
+//	for it.Next(ctx) {
+//		tick := it.Tick()
+//	}
+//	err := it.Err()
+type TicksIter struct {
+	service *QuotationService
+	market  string
+	opts    TickListOptions
+	since   int64
+
+	page   []*TradeTick
+	tick   *TradeTick
+	dayEnd bool // the day of opts.DaysAgo has no more pages
+	done   bool
+	err    error
+}
+
+// TicksIter returns an iterator over trades of market, newest first, which
+// stops at the first trade older than since. Trades of up to 7 days ago are
+// served; an earlier since ends the iteration with ErrTicksTooOld once they
+// are exhausted. A zero since iterates until the server has no more trades
+// for opts.DaysAgo.
+func (s *QuotationService) TicksIter(market string, opts *TickListOptions, since time.Time) *TicksIter {
+	it := &TicksIter{
+		service: s,
+		market:  market,
+	}
+	if opts != nil {
+		it.opts = *opts
+	}
+	if it.opts.Count == 0 {
+		it.opts.Count = maxTicksCount
+	}
+	if !since.IsZero() {
+		it.since = since.UnixNano() / int64(time.Millisecond)
+	}
+	return it
+}
+
+// Next advances to the next trade, fetching a page when needed. It returns
+// false at the end of the iteration or on error.
+func (it *TicksIter) Next(ctx context.Context) bool {
+	if it.err != nil || it.done {
+		return false
+	}
+
+	for len(it.page) == 0 {
+		if it.dayEnd {
+			if it.since == 0 {
+				it.done = true
+				return false
+			}
+			if it.opts.DaysAgo >= maxTicksDaysAgo {
+				it.err = ErrTicksTooOld
+				return false
+			}
+			// since is further back: go on from the end of the day before.
+			it.opts.DaysAgo++
+			it.opts.Cursor = 0
+			it.opts.To = ""
+			it.dayEnd = false
+		}
+
+		page, _, err := it.service.Ticks(ctx, it.market, &it.opts)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.dayEnd = len(page) < it.opts.Count
+		if len(page) > 0 {
+			it.page = page
+			it.opts.Cursor = page[len(page)-1].SequentialID
+		}
+	}
+
+	tick := it.page[0]
+	it.page = it.page[1:]
+
+	if tick.Timestamp < it.since {
+		it.page = nil
+		it.done = true
+		return false
+	}
+
+	it.tick = tick
+	return true
+}
+
+func (it *TicksIter) Tick() *TradeTick {
+	return it.tick
+}
+
+func (it *TicksIter) Err() error {
+	return it.err
+}
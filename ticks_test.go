@@ -0,0 +1,113 @@
+package upbit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+	"github.com/investing-kr/go-upbit/upbittest"
+)
+
+func TestTicksIter(t *testing.T) {
+	// Trades 1..10 at timestamps 1000..10000, served two per page.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/v1/trades/ticks" || q.Get("market") != upbit.KRW_BTC {
+			http.NotFound(w, r)
+			return
+		}
+
+		cursor := int64(11)
+		if c := q.Get("cursor"); c != "" {
+			cursor, _ = strconv.ParseInt(c, 10, 64)
+		}
+		count, _ := strconv.Atoi(q.Get("count"))
+
+		ticks := []*upbit.TradeTick{}
+		for id := cursor - 1; id > 0 && len(ticks) < count; id-- {
+			ticks = append(ticks, &upbit.TradeTick{Market: upbit.KRW_BTC, SequentialID: id, Timestamp: id * 1000})
+		}
+		json.NewEncoder(w).Encode(ticks)
+	}))
+	defer srv.Close()
+
+	client, err := upbit.NewClient(nil, &upbit.ClientOptions{ServerURL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	it := client.Quotations.TicksIter(upbit.KRW_BTC, &upbit.TickListOptions{Count: 2}, time.Unix(4, 0))
+	var ids []int64
+	for it.Next(ctx) {
+		ids = append(ids, it.Tick().SequentialID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 7 || ids[0] != 10 || ids[6] != 4 {
+		t.Errorf("ids = %v, want 10..4", ids)
+	}
+
+	it = client.Quotations.TicksIter(upbit.KRW_BTC, &upbit.TickListOptions{Count: 3}, time.Time{})
+	ids = ids[:0]
+	for it.Next(ctx) {
+		ids = append(ids, it.Tick().SequentialID)
+	}
+	if len(ids) != 10 || ids[9] != 1 {
+		t.Errorf("ids = %v, want 10..1", ids)
+	}
+}
+
+func TestTicksIterDayBoundary(t *testing.T) {
+	srv := upbittest.NewServer()
+	defer srv.Close()
+	srv.AddMarket(upbit.MarketCode{Market: upbit.KRW_BTC})
+
+	// Three trades before 00:00 UTC and two after.
+	midnight := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	for _, offset := range []time.Duration{-30 * time.Second, -20 * time.Second, -10 * time.Second, 10 * time.Second, 20 * time.Second} {
+		now := midnight.Add(offset)
+		srv.Now = func() time.Time { return now }
+		if err := srv.Trade(upbit.KRW_BTC, "50000000", "0.01"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	srv.Now = func() time.Time { return midnight.Add(time.Minute) }
+
+	client, err := srv.Client(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	it := client.Quotations.TicksIter(upbit.KRW_BTC, &upbit.TickListOptions{Count: 2}, midnight.Add(-25*time.Second))
+	var ids []int64
+	for it.Next(ctx) {
+		ids = append(ids, it.Tick().SequentialID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 4 || ids[0] != 5 || ids[3] != 2 {
+		t.Errorf("ids = %v, want 5..2", ids)
+	}
+
+	// Trades before the oldest day served end with an error after the
+	// ones that are served.
+	it = client.Quotations.TicksIter(upbit.KRW_BTC, nil, midnight.AddDate(0, 0, -10))
+	ids = ids[:0]
+	for it.Next(ctx) {
+		ids = append(ids, it.Tick().SequentialID)
+	}
+	if len(ids) != 5 || !errors.Is(it.Err(), upbit.ErrTicksTooOld) {
+		t.Errorf("ids = %v, err = %v, want 5..1 and %v", ids, it.Err(), upbit.ErrTicksTooOld)
+	}
+}
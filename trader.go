@@ -0,0 +1,44 @@
+package upbit
+
+import (
+	"context"
+	"net/http"
+)
+
+// OrderAPI is the part of OrderService a trading strategy needs. It is
+// implemented by *OrderService and *PaperTrader.
+type OrderAPI interface {
+	Order(ctx context.Context, orderReq *OrderRequest) (*Order, *http.Response, error)
+	GetOrderByUUID(ctx context.Context, uuid string) (*Order, *http.Response, error)
+	CancelOrderByUUID(ctx context.Context, uuid string) (*Order, *http.Response, error)
+	ListOrders(ctx context.Context, listOpt *OrderListOptions) ([]*Order, *http.Response, error)
+	Chances(ctx context.Context, market string) (*Chance, *http.Response, error)
+}
+
+// AccountAPI is implemented by *AccountService and *PaperTrader.
+type AccountAPI interface {
+	Accounts(ctx context.Context) ([]*Account, *http.Response, error)
+}
+
+// Trader places orders and reads balances, with real money through
+// Client.Trader or simulated through a PaperTrader.
+type Trader interface {
+	OrderAPI
+	AccountAPI
+}
+
+var (
+	_ OrderAPI   = (*OrderService)(nil)
+	_ AccountAPI = (*AccountService)(nil)
+	_ Trader     = (*PaperTrader)(nil)
+)
+
+type clientTrader struct {
+	*OrderService
+	*AccountService
+}
+
+// Trader returns the client's order and account services as a Trader.
+func (c *Client) Trader() Trader {
+	return clientTrader{c.Orders, c.Accounts}
+}
@@ -3,6 +3,7 @@ package upbit
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -12,27 +13,31 @@ const (
 	OrderStateCancel string = "cancel"
 )
 
+const (
+	MarketStateActive string = "active"
+)
+
 const (
 	OrderKindNormal string = "normal"
 	OrderKindWatch  string = "watch"
 )
 
 type Account struct {
-	Currency            string `json:"currency"`
-	Balance             string `json:"balance"`
-	Locked              string `json:"locked"`
-	AvgBuyPrice         string `json:"avg_buy_price"`
-	AvgBuyPriceModified bool   `json:"avg_buy_price_modified"`
-	UnitCurrency        string `json:"unit_currency"`
+	Currency            string  `json:"currency"`
+	Balance             Decimal `json:"balance"`
+	Locked              Decimal `json:"locked"`
+	AvgBuyPrice         Decimal `json:"avg_buy_price"`
+	AvgBuyPriceModified bool    `json:"avg_buy_price_modified"`
+	UnitCurrency        string  `json:"unit_currency"`
 }
 
 type OrderRequest struct {
-	Market     string `url:"market,omitempty"`
-	Side       string `url:"side,omitempty"`
-	Volume     string `url:"volume,omitempty"`
-	Price      string `url:"price,omitempty"`
-	OrdType    string `url:"ord_type,omitempty"`
-	Identifier string `url:"identifier,omitempty"`
+	Market     string  `url:"market,omitempty"`
+	Side       string  `url:"side,omitempty"`
+	Volume     Decimal `url:"volume,omitempty"`
+	Price      Decimal `url:"price,omitempty"`
+	OrdType    string  `url:"ord_type,omitempty"`
+	Identifier string  `url:"identifier,omitempty"`
 }
 
 type OrderListOptions struct {
@@ -64,18 +69,18 @@ type Order struct {
 	UUID            string    `json:"uuid"`
 	Side            string    `json:"side"`
 	OrdType         string    `json:"ord_type"`
-	Price           string    `json:"price"`
-	AvgPrice        string    `json:"avg_price" tabulate:"-"`
+	Price           Decimal   `json:"price"`
+	AvgPrice        Decimal   `json:"avg_price" tabulate:"-"`
 	State           string    `json:"state"`
 	Market          string    `json:"market"`
 	CreatedAt       time.Time `json:"created_at"`
-	Volume          string    `json:"volume"`
-	RemainingVolume string    `json:"remaining_volume"`
-	ReservedFee     string    `json:"reserved_fee" tabulate:"-"`
-	RemainingFee    string    `json:"remaining_fee" tabulate:"-"`
-	PaidFee         string    `json:"paid_fee" tabulate:"-"`
-	Locked          string    `json:"locked" tabulate:"-"`
-	ExecutedVolume  string    `json:"executed_volume" tabulate:"-"`
+	Volume          Decimal   `json:"volume"`
+	RemainingVolume Decimal   `json:"remaining_volume"`
+	ReservedFee     Decimal   `json:"reserved_fee" tabulate:"-"`
+	RemainingFee    Decimal   `json:"remaining_fee" tabulate:"-"`
+	PaidFee         Decimal   `json:"paid_fee" tabulate:"-"`
+	Locked          Decimal   `json:"locked" tabulate:"-"`
+	ExecutedVolume  Decimal   `json:"executed_volume" tabulate:"-"`
 	TradesCount     int       `json:"trades_count" tabulate:"-"`
 }
 
@@ -89,19 +94,20 @@ type MarketCode struct {
 type Orderbook struct {
 	Market         string          `json:"market"`
 	Timestamp      int64           `json:"timestamp"`
-	TotalAskSize   float64         `json:"total_ask_size"`
-	TotalBidSize   float64         `json:"total_bid_size"`
+	TotalAskSize   Decimal         `json:"total_ask_size"`
+	TotalBidSize   Decimal         `json:"total_bid_size"`
 	OrderbookUnits []OrderbookUnit `json:"orderbook_units"`
 
-	Type string `json:"type,omitempty"` // for websocket response
-	Code string `json:"code,omitempty"` // for websocket response
+	Type       string `json:"type,omitempty"`        // for websocket response
+	Code       string `json:"code,omitempty"`        // for websocket response
+	StreamType string `json:"stream_type,omitempty"` // for websocket response
 }
 
 type OrderbookUnit struct {
-	AskPrice float64 `json:"ask_price"`
-	BidPrice float64 `json:"bid_price"`
-	AskSize  float64 `json:"ask_size"`
-	BidSize  float64 `json:"bid_size"`
+	AskPrice Decimal `json:"ask_price"`
+	BidPrice Decimal `json:"bid_price"`
+	AskSize  Decimal `json:"ask_size"`
+	BidSize  Decimal `json:"bid_size"`
 }
 
 type Ticker struct {
@@ -111,29 +117,182 @@ type Ticker struct {
 	TradeDateKst        string  `json:"trade_date_kst"`
 	TradeTimeKst        string  `json:"trade_time_kst"`
 	TradeTimestamp      int64   `json:"trade_timestamp"`
-	OpeningPrice        float64 `json:"opening_price"`
-	HighPrice           float64 `json:"high_price"`
-	LowPrice            float64 `json:"low_price"`
-	TradePrice          float64 `json:"trade_price"`
-	PrevClosingPrice    float64 `json:"prev_closing_price"`
+	OpeningPrice        Decimal `json:"opening_price"`
+	HighPrice           Decimal `json:"high_price"`
+	LowPrice            Decimal `json:"low_price"`
+	TradePrice          Decimal `json:"trade_price"`
+	PrevClosingPrice    Decimal `json:"prev_closing_price"`
 	Change              string  `json:"change"`
-	ChangePrice         float64 `json:"change_price"`
-	ChangeRate          float64 `json:"change_rate"`
-	SignedChangePrice   float64 `json:"signed_change_price"`
-	SignedChangeRate    float64 `json:"signed_change_rate"`
-	TradeVolume         float64 `json:"trade_volume"`
-	AccTradePrice       float64 `json:"acc_trade_price"`
-	AccTradePrice24H    float64 `json:"acc_trade_price_24h"`
-	AccTradeVolume      float64 `json:"acc_trade_volume"`
-	AccTradeVolume24H   float64 `json:"acc_trade_volume_24h"`
-	Highest52_WeekPrice float64 `json:"highest_52_week_price"`
+	ChangePrice         Decimal `json:"change_price"`
+	ChangeRate          Decimal `json:"change_rate"`
+	SignedChangePrice   Decimal `json:"signed_change_price"`
+	SignedChangeRate    Decimal `json:"signed_change_rate"`
+	TradeVolume         Decimal `json:"trade_volume"`
+	AccTradePrice       Decimal `json:"acc_trade_price"`
+	AccTradePrice24H    Decimal `json:"acc_trade_price_24h"`
+	AccTradeVolume      Decimal `json:"acc_trade_volume"`
+	AccTradeVolume24H   Decimal `json:"acc_trade_volume_24h"`
+	Highest52_WeekPrice Decimal `json:"highest_52_week_price"`
 	Highest52_WeekDate  string  `json:"highest_52_week_date"`
-	Lowest52_WeekPrice  float64 `json:"lowest_52_week_price"`
+	Lowest52_WeekPrice  Decimal `json:"lowest_52_week_price"`
 	Lowest52_WeekDate   string  `json:"lowest_52_week_date"`
 	Timestamp           int64   `json:"timestamp"`
 
-	Type string `json:"type,omitempty"` // for websocket response
-	Code string `json:"code,omitempty"` // for websocket response
+	Type       string `json:"type,omitempty"`        // for websocket response
+	Code       string `json:"code,omitempty"`        // for websocket response
+	StreamType string `json:"stream_type,omitempty"` // for websocket response
+}
+
+// TradeTick is a trade returned by the trades/ticks REST endpoint.
+type TradeTick struct {
+	Market           string  `json:"market"`
+	TradeDateUtc     string  `json:"trade_date_utc"`
+	TradeTimeUtc     string  `json:"trade_time_utc"`
+	Timestamp        int64   `json:"timestamp"`
+	TradePrice       Decimal `json:"trade_price"`
+	TradeVolume      Decimal `json:"trade_volume"`
+	PrevClosingPrice Decimal `json:"prev_closing_price"`
+	ChangePrice      Decimal `json:"change_price"`
+	AskBid           string  `json:"ask_bid"`
+	SequentialID     int64   `json:"sequential_id"`
+}
+
+// Trade converts the tick into the websocket representation.
+func (t *TradeTick) Trade() *Trade {
+	return &Trade{
+		Type:             WebsocketTypeTrade,
+		Code:             t.Market,
+		TradePrice:       t.TradePrice,
+		TradeVolume:      t.TradeVolume,
+		AskBid:           t.AskBid,
+		PrevClosingPrice: t.PrevClosingPrice,
+		ChangePrice:      t.ChangePrice,
+		TradeDate:        t.TradeDateUtc,
+		TradeTime:        t.TradeTimeUtc,
+		TradeTimestamp:   t.Timestamp,
+		Timestamp:        t.Timestamp,
+		SequentialID:     t.SequentialID,
+	}
+}
+
+// Tick converts the trade into the REST representation.
+func (t *Trade) Tick() *TradeTick {
+	return &TradeTick{
+		Market:           t.Code,
+		TradeDateUtc:     t.TradeDate,
+		TradeTimeUtc:     t.TradeTime,
+		Timestamp:        t.TradeTimestamp,
+		TradePrice:       t.TradePrice,
+		TradeVolume:      t.TradeVolume,
+		PrevClosingPrice: t.PrevClosingPrice,
+		ChangePrice:      t.ChangePrice,
+		AskBid:           t.AskBid,
+		SequentialID:     t.SequentialID,
+	}
+}
+
+// Trade is a trade event of the websocket trade stream.
+type Trade struct {
+	Type             string  `json:"type"`
+	Code             string  `json:"code"`
+	TradePrice       Decimal `json:"trade_price"`
+	TradeVolume      Decimal `json:"trade_volume"`
+	AskBid           string  `json:"ask_bid"`
+	PrevClosingPrice Decimal `json:"prev_closing_price"`
+	Change           string  `json:"change"`
+	ChangePrice      Decimal `json:"change_price"`
+	TradeDate        string  `json:"trade_date"`
+	TradeTime        string  `json:"trade_time"`
+	TradeTimestamp   int64   `json:"trade_timestamp"`
+	Timestamp        int64   `json:"timestamp"`
+	SequentialID     int64   `json:"sequential_id"`
+	StreamType       string  `json:"stream_type"`
+}
+
+// MyOrderEvent is an order or fill event of the private myOrder stream.
+type MyOrderEvent struct {
+	Type            string  `json:"type"`
+	Code            string  `json:"code"`
+	UUID            string  `json:"uuid"`
+	AskBid          string  `json:"ask_bid"`
+	OrderType       string  `json:"order_type"`
+	State           string  `json:"state"`
+	TradeUUID       string  `json:"trade_uuid"`
+	Price           Decimal `json:"price"`
+	AvgPrice        Decimal `json:"avg_price"`
+	Volume          Decimal `json:"volume"`
+	RemainingVolume Decimal `json:"remaining_volume"`
+	ExecutedVolume  Decimal `json:"executed_volume"`
+	TradesCount     int     `json:"trades_count"`
+	ReservedFee     Decimal `json:"reserved_fee"`
+	RemainingFee    Decimal `json:"remaining_fee"`
+	PaidFee         Decimal `json:"paid_fee"`
+	Locked          Decimal `json:"locked"`
+	ExecutedFunds   Decimal `json:"executed_funds"`
+	TradeFee        Decimal `json:"trade_fee"`
+	IsMaker         bool    `json:"is_maker"`
+	Identifier      string  `json:"identifier"`
+	TradeTimestamp  int64   `json:"trade_timestamp"`
+	OrderTimestamp  int64   `json:"order_timestamp"`
+	Timestamp       int64   `json:"timestamp"`
+	StreamType      string  `json:"stream_type"`
+}
+
+// Order converts the event into the REST representation so that handlers can
+// be shared with OrderService.
+func (e *MyOrderEvent) Order() *Order {
+	state := e.State
+	if state == "trade" {
+		// A partial fill; the order itself is still waiting.
+		state = OrderStateWait
+	}
+
+	return &Order{
+		UUID:            e.UUID,
+		Side:            strings.ToLower(e.AskBid),
+		OrdType:         e.OrderType,
+		Price:           e.Price,
+		AvgPrice:        e.AvgPrice,
+		State:           state,
+		Market:          e.Code,
+		CreatedAt:       time.Unix(0, e.OrderTimestamp*int64(time.Millisecond)),
+		Volume:          e.Volume,
+		RemainingVolume: e.RemainingVolume,
+		ReservedFee:     e.ReservedFee,
+		RemainingFee:    e.RemainingFee,
+		PaidFee:         e.PaidFee,
+		Locked:          e.Locked,
+		ExecutedVolume:  e.ExecutedVolume,
+		TradesCount:     e.TradesCount,
+	}
+}
+
+// MyAssetEvent is a balance change event of the private myAsset stream.
+type MyAssetEvent struct {
+	Type      string `json:"type"`
+	AssetUUID string `json:"asset_uuid"`
+	Assets    []struct {
+		Currency string  `json:"currency"`
+		Balance  Decimal `json:"balance"`
+		Locked   Decimal `json:"locked"`
+	} `json:"assets"`
+	AssetTimestamp int64  `json:"asset_timestamp"`
+	Timestamp      int64  `json:"timestamp"`
+	StreamType     string `json:"stream_type"`
+}
+
+// Accounts converts the event into the REST representation. Only Currency,
+// Balance and Locked are carried by the stream.
+func (e *MyAssetEvent) Accounts() []*Account {
+	accounts := make([]*Account, 0, len(e.Assets))
+	for _, asset := range e.Assets {
+		accounts = append(accounts, &Account{
+			Currency: asset.Currency,
+			Balance:  asset.Balance,
+			Locked:   asset.Locked,
+		})
+	}
+	return accounts
 }
 
 type WebsocketRequest struct {
@@ -168,65 +327,194 @@ func (r *WebsocketRequest) MarshalJSON() ([]byte, error) {
 }
 
 type Chance struct {
-	BidFee      string `json:"bid_fee"`
-	AskFee      string `json:"ask_fee"`
-	MakerBidFee string `json:"maker_bid_fee"`
-	MakerAskFee string `json:"maker_ask_fee"`
+	BidFee      Decimal `json:"bid_fee"`
+	AskFee      Decimal `json:"ask_fee"`
+	MakerBidFee Decimal `json:"maker_bid_fee"`
+	MakerAskFee Decimal `json:"maker_ask_fee"`
 	Market      struct {
 		ID         string   `json:"id"`
 		Name       string   `json:"name"`
 		OrderTypes []string `json:"order_types"`
+		BidTypes   []string `json:"bid_types"`
+		AskTypes   []string `json:"ask_types"`
 		OrderSides []string `json:"order_sides"`
 		Bid        struct {
 			Currency  string  `json:"currency"`
-			PriceUnit float64 `json:"price_unit"`
-			MinTotal  float64 `json:"min_total"`
+			PriceUnit Decimal `json:"price_unit"`
+			MinTotal  Decimal `json:"min_total"`
 		} `json:"bid"`
 		Ask struct {
 			Currency  string  `json:"currency"`
-			PriceUnit float64 `json:"price_unit"`
-			MinTotal  float64 `json:"min_total"`
+			PriceUnit Decimal `json:"price_unit"`
+			MinTotal  Decimal `json:"min_total"`
 		} `json:"ask"`
-		MaxTotal string `json:"max_total"`
-		State    string `json:"state"`
+		MaxTotal Decimal `json:"max_total"`
+		State    string  `json:"state"`
 	} `json:"market"`
-	BidAccount struct {
-		Currency            string `json:"currency"`
-		Balance             string `json:"balance"`
-		Locked              string `json:"locked"`
-		AvgBuyPrice         string `json:"avg_buy_price"`
-		AvgBuyPriceModified bool   `json:"avg_buy_price_modified"`
-		UnitCurrency        string `json:"unit_currency"`
-	} `json:"bid_account"`
-	AskAccount struct {
-		Currency            string `json:"currency"`
-		Balance             string `json:"balance"`
-		Locked              string `json:"locked"`
-		AvgBuyPrice         string `json:"avg_buy_price"`
-		AvgBuyPriceModified bool   `json:"avg_buy_price_modified"`
-		UnitCurrency        string `json:"unit_currency"`
-	} `json:"ask_account"`
+	BidAccount Account `json:"bid_account"`
+	AskAccount Account `json:"ask_account"`
+
+	// region is the region of the client that fetched the chance.
+	region Region
 }
 
 type Candle struct {
 	Market               string  `json:"market"`
 	CandleDateTimeUtc    string  `json:"candle_date_time_utc"`
 	CandleDateTimeKst    string  `json:"candle_date_time_kst"`
-	OpeningPrice         float64 `json:"opening_price"`
-	HighPrice            float64 `json:"high_price"`
-	LowPrice             float64 `json:"low_price"`
-	TradePrice           float64 `json:"trade_price"`
+	OpeningPrice         Decimal `json:"opening_price"`
+	HighPrice            Decimal `json:"high_price"`
+	LowPrice             Decimal `json:"low_price"`
+	TradePrice           Decimal `json:"trade_price"`
 	Timestamp            int64   `json:"timestamp"`
-	CandleAccTradePrice  float64 `json:"candle_acc_trade_price"`
-	CandleAccTradeVolume float64 `json:"candle_acc_trade_volume"`
-	PrevClosingPrice     float64 `json:"prev_closing_price"`
-	ChangePrice          float64 `json:"change_price"`
-	ChangeRate           float64 `json:"change_rate"`
+	CandleAccTradePrice  Decimal `json:"candle_acc_trade_price"`
+	CandleAccTradeVolume Decimal `json:"candle_acc_trade_volume"`
+	PrevClosingPrice     Decimal `json:"prev_closing_price"`
+	ChangePrice          Decimal `json:"change_price"`
+	ChangeRate           Decimal `json:"change_rate"`
 	FirstDayOfPeriod     string  `json:"first_day_of_period"`
 }
 
 type CoinAddress struct {
 	Currency         string `json:"currency"`
+	NetType          string `json:"net_type"`
 	DepositAddress   string `json:"deposit_address"`
 	SecondaryAddress string `json:"secondary_address"`
 }
+
+// CoinAddressCreating is the response of generate_coin_address while the
+// address is still being issued.
+type CoinAddressCreating struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+const (
+	DepositStateProcessing          string = "PROCESSING"
+	DepositStateAccepted            string = "ACCEPTED"
+	DepositStateCancelled           string = "CANCELLED"
+	DepositStateRejected            string = "REJECTED"
+	DepositStateTravelRuleSuspected string = "TRAVEL_RULE_SUSPECTED"
+	DepositStateRefunding           string = "REFUNDING"
+	DepositStateRefunded            string = "REFUNDED"
+)
+
+type Deposit struct {
+	Type            string    `json:"type"`
+	UUID            string    `json:"uuid"`
+	Currency        string    `json:"currency"`
+	NetType         string    `json:"net_type"`
+	TxID            string    `json:"txid"`
+	State           string    `json:"state"`
+	CreatedAt       time.Time `json:"created_at"`
+	DoneAt          time.Time `json:"done_at"`
+	Amount          Decimal   `json:"amount"`
+	Fee             Decimal   `json:"fee"`
+	TransactionType string    `json:"transaction_type"`
+}
+
+type DepositListOptions struct {
+	Currency string   `url:"currency,omitempty"`
+	State    string   `url:"state,omitempty"`
+	States   []string `url:"states,brackets"`
+	UUIDs    []string `url:"uuids,brackets"`
+	TxIDs    []string `url:"txids,brackets"`
+	Limit    int      `url:"limit,omitempty"`
+	Page     int      `url:"page,omitempty"`
+	OrderBy  string   `url:"order_by,omitempty"`
+}
+
+type DepositKRWRequest struct {
+	Amount        Decimal `url:"amount,omitempty"`
+	TwoFactorType string  `url:"two_factor_type,omitempty"`
+}
+
+const (
+	WithdrawStateWaiting    string = "WAITING"
+	WithdrawStateProcessing string = "PROCESSING"
+	WithdrawStateDone       string = "DONE"
+	WithdrawStateFailed     string = "FAILED"
+	WithdrawStateCancelled  string = "CANCELLED"
+	WithdrawStateRejected   string = "REJECTED"
+)
+
+const (
+	TransactionTypeDefault  string = "default"  // 일반 입출금
+	TransactionTypeInternal string = "internal" // 바로 입출금
+)
+
+const (
+	TwoFactorTypeKakao    string = "kakao"
+	TwoFactorTypeNaver    string = "naver"
+	TwoFactorTypeHana     string = "hana"
+	TwoFactorTypeKakaoPay string = "kakao_pay"
+)
+
+type Withdraw struct {
+	Type            string    `json:"type"`
+	UUID            string    `json:"uuid"`
+	Currency        string    `json:"currency"`
+	NetType         string    `json:"net_type"`
+	TxID            string    `json:"txid"`
+	State           string    `json:"state"`
+	CreatedAt       time.Time `json:"created_at"`
+	DoneAt          time.Time `json:"done_at"`
+	Amount          Decimal   `json:"amount"`
+	Fee             Decimal   `json:"fee"`
+	TransactionType string    `json:"transaction_type"`
+}
+
+type WithdrawListOptions struct {
+	Currency string   `url:"currency,omitempty"`
+	State    string   `url:"state,omitempty"`
+	UUIDs    []string `url:"uuids,brackets"`
+	TxIDs    []string `url:"txids,brackets"`
+	Limit    int      `url:"limit,omitempty"`
+	Page     int      `url:"page,omitempty"`
+	OrderBy  string   `url:"order_by,omitempty"`
+}
+
+type WithdrawCoinRequest struct {
+	Currency         string  `url:"currency,omitempty"`
+	NetType          string  `url:"net_type,omitempty"`
+	Amount           Decimal `url:"amount,omitempty"`
+	Address          string  `url:"address,omitempty"`
+	SecondaryAddress string  `url:"secondary_address,omitempty"`
+	TransactionType  string  `url:"transaction_type,omitempty"`
+}
+
+type WithdrawKRWRequest struct {
+	Amount        Decimal `url:"amount,omitempty"`
+	TwoFactorType string  `url:"two_factor_type,omitempty"`
+}
+
+type WithdrawChance struct {
+	MemberLevel struct {
+		SecurityLevel         int  `json:"security_level"`
+		FeeLevel              int  `json:"fee_level"`
+		EmailVerified         bool `json:"email_verified"`
+		IdentityAuthVerified  bool `json:"identity_auth_verified"`
+		BankAccountVerified   bool `json:"bank_account_verified"`
+		TwoFactorAuthVerified bool `json:"two_factor_auth_verified"`
+		Locked                bool `json:"locked"`
+		WalletLocked          bool `json:"wallet_locked"`
+	} `json:"member_level"`
+	Currency struct {
+		Code          string   `json:"code"`
+		WithdrawFee   Decimal  `json:"withdraw_fee"`
+		IsCoin        bool     `json:"is_coin"`
+		WalletState   string   `json:"wallet_state"`
+		WalletSupport []string `json:"wallet_support"`
+	} `json:"currency"`
+	Account       Account `json:"account"`
+	WithdrawLimit struct {
+		Currency          string  `json:"currency"`
+		Minimum           Decimal `json:"minimum"`
+		Onetime           Decimal `json:"onetime"`
+		Daily             Decimal `json:"daily"`
+		RemainingDaily    Decimal `json:"remaining_daily"`
+		RemainingDailyKRW Decimal `json:"remaining_daily_krw"`
+		Fixed             int     `json:"fixed"`
+		CanWithdraw       bool    `json:"can_withdraw"`
+	} `json:"withdraw_limit"`
+}
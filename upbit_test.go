@@ -154,3 +154,57 @@ func TestCoinAddresses(t *testing.T) {
 	}
 	t.Log(addresses)
 }
+
+func TestWithdraws(t *testing.T) {
+	ctx := context.Background()
+
+	withdraws, _, err := c.Withdraws.ListWithdraws(ctx, &upbit.WithdrawListOptions{
+		Currency: "BTC",
+		Limit:    10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, w := range withdraws {
+		t.Logf("%+v", w)
+	}
+
+	chance, _, err := c.Withdraws.Chance(ctx, "BTC", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("%+v", chance)
+}
+
+func TestDeposits(t *testing.T) {
+	ctx := context.Background()
+
+	deposits, _, err := c.Deposits.ListDeposits(ctx, &upbit.DepositListOptions{
+		Currency: "KRW",
+		Limit:    10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range deposits {
+		t.Logf("%+v", d)
+	}
+}
+
+func TestOrderbook(t *testing.T) {
+	ctx := context.Background()
+
+	ob, _, err := c.Quotations.OrderbookMarket(ctx, upbit.KRW_BTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	book, err := upbit.NewBook(ob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(book.BestBid())
+	t.Log(book.BestAsk())
+}
@@ -0,0 +1,325 @@
+package upbittest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/investing-kr/go-upbit"
+	"github.com/investing-kr/go-upbit/internal/ledger"
+	"github.com/investing-kr/go-upbit/internal/ledger/ledgerconv"
+)
+
+type market struct {
+	code        upbit.MarketCode
+	quote, base string
+	minTotal    upbit.Decimal
+
+	// asks and bids are the liquidity of other traders, best first.
+	asks, bids []upbit.BookLevel
+	candles    map[string][]*upbit.Candle
+	ticker     upbit.Ticker
+	// trades are the trades of other traders, oldest first.
+	trades []*upbit.TradeTick
+}
+
+// AddMarket lists a market. Its minimum order total is the one of its quote
+// currency on Upbit.
+func (s *Server) AddMarket(code upbit.MarketCode) error {
+	quote, base, err := upbit.ParseMarket(code.Market)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.market(code.Market); err == nil {
+		return fmt.Errorf("upbittest: market %s already listed", code.Market)
+	}
+	s.markets = append(s.markets, &market{
+		code:     code,
+		quote:    quote,
+		base:     base,
+		minTotal: upbit.Decimal(ledger.MinTotals[quote]),
+		candles:  map[string][]*upbit.Candle{},
+		ticker:   upbit.Ticker{Market: code.Market},
+	})
+	return nil
+}
+
+// SetBalance sets the available balance of currency.
+func (s *Server) SetBalance(currency string, balance upbit.Decimal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ledger.Account(currency).Balance = string(balance)
+}
+
+// SetOrderbook replaces the liquidity other traders offer in market. Market
+// and limit orders that cross it are filled immediately.
+func (s *Server) SetOrderbook(market string, asks, bids []upbit.BookLevel) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, err := s.market(market)
+	if err != nil {
+		return err
+	}
+
+	m.asks = append([]upbit.BookLevel(nil), asks...)
+	m.bids = append([]upbit.BookLevel(nil), bids...)
+	sort.Slice(m.asks, func(i, j int) bool { return m.asks[i].Price.LessThan(m.asks[j].Price) })
+	sort.Slice(m.bids, func(i, j int) bool { return m.bids[i].Price.GreaterThan(m.bids[j].Price) })
+	return nil
+}
+
+// SetTicker replaces the ticker of ticker.Market.
+func (s *Server) SetTicker(ticker upbit.Ticker) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, err := s.market(ticker.Market)
+	if err != nil {
+		return err
+	}
+	m.ticker = ticker
+	return nil
+}
+
+// AddCandles appends candles of unit, the path of the candle API such as
+// "minutes/1" or "days", to market. Candles must be added oldest first.
+func (s *Server) AddCandles(market, unit string, candles ...*upbit.Candle) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, err := s.market(market)
+	if err != nil {
+		return err
+	}
+	m.candles[unit] = append(m.candles[unit], candles...)
+	return nil
+}
+
+// Trade simulates a trade of volume at price in market between other
+// traders at Now, served by trades/ticks. Waiting limit orders at price or
+// better are filled at their own price, oldest first, up to volume.
+func (s *Server) Trade(market string, price, volume upbit.Decimal) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, err := s.market(market)
+	if err != nil {
+		return err
+	}
+
+	// Both sides share the volume of the trade.
+	levels := []ledger.Level{{Price: string(price), Size: string(volume)}}
+	for _, o := range s.ledger.Orders {
+		if o.Market == market && o.State == upbit.OrderStateWait {
+			levels = s.ledger.Match(o, levels, true)
+		}
+	}
+
+	now := s.Now().UTC()
+	s.sequence++
+	m.trades = append(m.trades, &upbit.TradeTick{
+		Market:       market,
+		TradeDateUtc: now.Format("2006-01-02"),
+		TradeTimeUtc: now.Format("15:04:05"),
+		Timestamp:    now.UnixNano() / int64(time.Millisecond),
+		TradePrice:   price,
+		TradeVolume:  volume,
+		SequentialID: s.sequence,
+	})
+
+	m.ticker.TradePrice = price
+	m.ticker.TradeVolume = volume
+	m.ticker.TradeTimestamp = now.UnixNano() / int64(time.Millisecond)
+	m.ticker.Timestamp = m.ticker.TradeTimestamp
+	m.ticker.AccTradeVolume = m.ticker.AccTradeVolume.Add(volume)
+	m.ticker.AccTradePrice = m.ticker.AccTradePrice.Add(price.Mul(volume))
+	return nil
+}
+
+// Accounts returns a copy of the accounts with a balance.
+func (s *Server) Accounts() []*upbit.Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ledgerconv.Holdings(s.ledger)
+}
+
+// Orders returns a copy of every order placed, oldest first.
+func (s *Server) Orders() []*upbit.Order {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orders := make([]*upbit.Order, 0, len(s.ledger.Orders))
+	for _, o := range s.ledger.Orders {
+		orders = append(orders, ledgerconv.Order(o))
+	}
+	return orders
+}
+
+func (s *Server) market(code string) (*market, *apiError) {
+	for _, m := range s.markets {
+		if m.code.Market == code {
+			return m, nil
+		}
+	}
+	return nil, errorf(http.StatusNotFound, "market_does_not_exist", "market %s does not exist", code)
+}
+
+func (m *market) orderbook() *upbit.Orderbook {
+	ob := &upbit.Orderbook{Market: m.code.Market, TotalAskSize: "0", TotalBidSize: "0"}
+	for i := 0; i < len(m.asks) || i < len(m.bids); i++ {
+		var unit upbit.OrderbookUnit
+		if i < len(m.asks) {
+			unit.AskPrice, unit.AskSize = m.asks[i].Price, m.asks[i].Size
+			ob.TotalAskSize = ob.TotalAskSize.Add(unit.AskSize)
+		}
+		if i < len(m.bids) {
+			unit.BidPrice, unit.BidSize = m.bids[i].Price, m.bids[i].Size
+			ob.TotalBidSize = ob.TotalBidSize.Add(unit.BidSize)
+		}
+		ob.OrderbookUnits = append(ob.OrderbookUnits, unit)
+	}
+	return ob
+}
+
+func (s *Server) placeOrder(req *upbit.OrderRequest) (*upbit.Order, *apiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, err := s.market(req.Market)
+	if err != nil {
+		return nil, err
+	}
+	if req.Side != upbit.SideBid && req.Side != upbit.SideAsk {
+		return nil, errorf(http.StatusBadRequest, "validation_error", "side must be bid or ask")
+	}
+	if req.Identifier != "" {
+		if _, err := s.findOrder("", req.Identifier); err == nil {
+			return nil, errorf(http.StatusBadRequest, "validation_error", "identifier %s is already used", req.Identifier)
+		}
+	}
+	if err := checkDecimals(req); err != nil {
+		return nil, err
+	}
+
+	var total upbit.Decimal
+	switch {
+	case req.OrdType == upbit.OrdTypeLimit && req.Price != "" && req.Volume != "":
+		if !upbit.RoundPrice(req.Market, req.Price, upbit.RoundNearest).Equal(req.Price) {
+			return nil, errorf(http.StatusBadRequest, "invalid_price_"+req.Side, "price %s is off the price unit", req.Price)
+		}
+		total = req.Price.Mul(req.Volume)
+	case req.OrdType == upbit.OrdTypePrice && req.Side == upbit.SideBid && req.Price != "" && req.Volume == "":
+		total = req.Price
+	case req.OrdType == upbit.OrdTypeMarket && req.Side == upbit.SideAsk && req.Volume != "" && req.Price == "":
+	default:
+		return nil, errorf(http.StatusBadRequest, "validation_error", "invalid %s order of side %s", req.OrdType, req.Side)
+	}
+	if total != "" && total.LessThan(m.minTotal) {
+		return nil, errorf(http.StatusBadRequest, "under_min_total_"+req.Side, "minimum order total is %s %s", m.minTotal, m.quote)
+	}
+
+	o, placeErr := s.ledger.Place(ledgerconv.Request(req, s.Fee), s.Now())
+	var short *ledger.InsufficientError
+	if errors.As(placeErr, &short) {
+		return nil, errorf(http.StatusBadRequest, "insufficient_funds_"+req.Side, "%v", short)
+	}
+	if placeErr != nil {
+		return nil, errorf(http.StatusBadRequest, "validation_error", "%v", placeErr)
+	}
+
+	if o.Side == upbit.SideBid {
+		m.asks = ledgerconv.BookLevels(s.ledger.Match(o, ledgerconv.Levels(m.asks), false))
+	} else {
+		m.bids = ledgerconv.BookLevels(s.ledger.Match(o, ledgerconv.Levels(m.bids), false))
+	}
+	return ledgerconv.Order(o), nil
+}
+
+func checkDecimals(req *upbit.OrderRequest) *apiError {
+	for _, d := range []struct {
+		name  string
+		value upbit.Decimal
+	}{{"volume", req.Volume}, {"price", req.Price}} {
+		if d.value == "" {
+			continue
+		}
+		if v, err := upbit.NewDecimal(string(d.value)); err != nil || v.Sign() <= 0 {
+			return errorf(http.StatusBadRequest, "invalid_"+d.name+"_"+req.Side, "invalid %s %q", d.name, d.value)
+		}
+	}
+	return nil
+}
+
+func (s *Server) findOrder(uuid, identifier string) (*ledger.Order, *apiError) {
+	if uuid == "" && identifier == "" {
+		return nil, errorf(http.StatusBadRequest, "validation_error", "uuid or identifier is required")
+	}
+	if o := s.ledger.Find(uuid, identifier); o != nil {
+		return o, nil
+	}
+	return nil, errorf(http.StatusNotFound, "order_not_found", "주문을 찾지 못했습니다.")
+}
+
+func (s *Server) getOrder(uuid, identifier string) (*upbit.Order, *apiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, err := s.findOrder(uuid, identifier)
+	if err != nil {
+		return nil, err
+	}
+	return ledgerconv.Order(o), nil
+}
+
+func (s *Server) cancelOrder(uuid, identifier string) (*upbit.Order, *apiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, err := s.findOrder(uuid, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if o.State != upbit.OrderStateWait {
+		return nil, errorf(http.StatusBadRequest, "validation_error", "order is %s", o.State)
+	}
+
+	s.ledger.Cancel(o)
+
+	return ledgerconv.Order(o), nil
+}
+
+func (s *Server) listOrders(q map[string][]string) []*upbit.Order {
+	get := func(key string) string {
+		if v := q[key]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+
+	page, _ := strconv.Atoi(get("page"))
+	limit, _ := strconv.Atoi(get("limit"))
+	query := &ledger.Query{
+		Market:      get("market"),
+		State:       get("state"),
+		States:      q["states[]"],
+		UUIDs:       q["uuids[]"],
+		Identifiers: q["identifiers[]"],
+		Page:        page,
+		Limit:       limit,
+		OrderBy:     get("order_by"),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return ledgerconv.Orders(s.ledger.List(query))
+}
@@ -0,0 +1,484 @@
+// Package upbittest provides a fake Upbit exchange for tests.
+//
+// A Server answers the REST API on an httptest server. It authenticates
+// private requests exactly like Upbit, checking the JWT signature, nonce and
+// query_hash, serves markets, quotations and accounts from state set up by the
+// test, and matches orders against the order book and simulated trades:
+//
+//	srv := upbittest.NewServer()
+//	defer srv.Close()
+//
+//	srv.AddMarket(upbit.MarketCode{Market: upbit.KRW_BTC, EnglishName: "Bitcoin"})
+//	srv.SetBalance("KRW", "1000000")
+//	client, _ := srv.Client(nil)
+//
+//	order, _, _ := client.Orders.Order(ctx, &upbit.OrderRequest{...})
+//	srv.Trade(upbit.KRW_BTC, "50000000", "0.01") // fills order
+package upbittest
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"github.com/investing-kr/go-upbit"
+	"github.com/investing-kr/go-upbit/internal/ledger"
+	"github.com/investing-kr/go-upbit/internal/ledger/ledgerconv"
+)
+
+const (
+	AccessKey = "upbittest-access-key"
+	SecretKey = "upbittest-secret-key"
+)
+
+// Server is a fake Upbit exchange. Its methods are safe for concurrent use
+// with the requests it serves.
+type Server struct {
+	*httptest.Server
+
+	// AccessKey and SecretKey are the credentials private requests must be
+	// signed with, AccessKey and SecretKey by default.
+	AccessKey string
+	SecretKey string
+
+	// Fee is the trading fee rate of both sides, 0.0005 by default.
+	Fee upbit.Decimal
+
+	// Now returns the time used for order and trade timestamps.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	markets []*market
+	ledger  *ledger.Ledger
+	nonces  map[string]bool
+	// sequence is the sequential_id of the last trade.
+	sequence int64
+}
+
+// NewServer starts a Server without markets or balances.
+func NewServer() *Server {
+	s := &Server{
+		AccessKey: AccessKey,
+		SecretKey: SecretKey,
+		Fee:       "0.0005",
+		Now:       time.Now,
+		ledger:    ledger.New("KRW"),
+		nonces:    map[string]bool{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/market/all", s.handleMarkets)
+	mux.HandleFunc("/v1/candles/", s.handleCandles)
+	mux.HandleFunc("/v1/ticker", s.handleTicker)
+	mux.HandleFunc("/v1/trades/ticks", s.handleTicks)
+	mux.HandleFunc("/v1/orderbook", s.handleOrderbook)
+	mux.HandleFunc("/v1/accounts", s.private(s.handleAccounts))
+	mux.HandleFunc("/v1/orders/chance", s.private(s.handleChance))
+	mux.HandleFunc("/v1/orders", s.private(s.handleOrders))
+	mux.HandleFunc("/v1/order", s.private(s.handleOrder))
+
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Client returns a client of the server signing with the server's keys.
+// opts, which may be nil, is copied and its ServerURL and keys overwritten.
+func (s *Server) Client(opts *upbit.ClientOptions) (*upbit.Client, error) {
+	o := upbit.ClientOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	o.ServerURL = s.URL
+	o.AccessKey = s.AccessKey
+	o.SecretKey = s.SecretKey
+	return upbit.NewClient(s.Server.Client(), &o)
+}
+
+// apiError is the error body of Upbit.
+type apiError struct {
+	status  int
+	name    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.name + ": " + e.message
+}
+
+func errorf(status int, name, format string, args ...interface{}) *apiError {
+	return &apiError{status: status, name: name, message: fmt.Sprintf(format, args...)}
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	var body struct {
+		Error struct {
+			Name    string `json:"name"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	body.Error.Name = err.name
+	body.Error.Message = err.message
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(err.status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(v)
+}
+
+// private wraps handler with the authentication of the exchange API.
+func (s *Server) private(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := s.authenticate(r); err != nil {
+			writeError(w, err)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// authenticate verifies the JWT of r like Upbit: HS256 signed with the
+// secret key, the known access key, an unused nonce and, if r has a query,
+// the SHA512 query_hash of its unescaped query string.
+func (s *Server) authenticate(r *http.Request) *apiError {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return errorf(http.StatusUnauthorized, "jwt_verification", "Authorization header is missing")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(strings.TrimPrefix(auth, "Bearer "), claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return []byte(s.SecretKey), nil
+	})
+	if err != nil {
+		return errorf(http.StatusUnauthorized, "jwt_verification", "%v", err)
+	}
+
+	if claims["access_key"] != s.AccessKey {
+		return errorf(http.StatusUnauthorized, "invalid_access_key", "unknown access key")
+	}
+
+	nonce, _ := claims["nonce"].(string)
+	if nonce == "" {
+		return errorf(http.StatusUnauthorized, "jwt_verification", "nonce is missing")
+	}
+	s.mu.Lock()
+	used := s.nonces[nonce]
+	s.nonces[nonce] = true
+	s.mu.Unlock()
+	if used {
+		return errorf(http.StatusUnauthorized, "nonce_used", "nonce %s is already used", nonce)
+	}
+
+	if r.URL.RawQuery == "" {
+		return nil
+	}
+	queryString, err := url.QueryUnescape(r.URL.RawQuery)
+	if err != nil {
+		return errorf(http.StatusUnauthorized, "invalid_query_payload", "%v", err)
+	}
+	sum := sha512.Sum512([]byte(queryString))
+	if claims["query_hash_alg"] != "SHA512" || claims["query_hash"] != hex.EncodeToString(sum[:]) {
+		return errorf(http.StatusUnauthorized, "invalid_query_payload", "query_hash does not match")
+	}
+	return nil
+}
+
+// params returns the query of r, merged with its JSON body if it has one.
+func params(r *http.Request) (url.Values, *apiError) {
+	q := r.URL.Query()
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		return q, nil
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, errorf(http.StatusBadRequest, "invalid_parameter", "%v", err)
+	}
+	for k, v := range body {
+		q.Set(k, fmt.Sprint(v))
+	}
+	return q, nil
+}
+
+func (s *Server) handleMarkets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	markets := make([]upbit.MarketCode, 0, len(s.markets))
+	for _, m := range s.markets {
+		markets = append(markets, m.code)
+	}
+	writeJSON(w, markets)
+}
+
+func (s *Server) handleCandles(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	unit := strings.TrimPrefix(r.URL.Path, "/v1/candles/")
+
+	count := 1
+	if c := q.Get("count"); c != "" {
+		n, err := strconv.Atoi(c)
+		if err != nil || n < 1 || n > 200 {
+			writeError(w, errorf(http.StatusBadRequest, "validation_error", "count must be between 1 and 200"))
+			return
+		}
+		count = n
+	}
+
+	var to time.Time
+	if t := q.Get("to"); t != "" {
+		var err error
+		if to, err = parseTime(t); err != nil {
+			writeError(w, errorf(http.StatusBadRequest, "validation_error", "invalid to %q", t))
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, err := s.market(q.Get("market"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// Candles are kept oldest first and answered newest first.
+	candles := []*upbit.Candle{}
+	stored := m.candles[unit]
+	for i := len(stored) - 1; i >= 0 && len(candles) < count; i-- {
+		if !to.IsZero() {
+			start, _ := parseTime(stored[i].CandleDateTimeUtc)
+			if !start.Before(to) {
+				continue
+			}
+		}
+		candles = append(candles, stored[i])
+	}
+	writeJSON(w, candles)
+}
+
+// handleTicks answers the trades of a UTC day, today or daysAgo, newest
+// first, before cursor if given. to is not supported.
+func (s *Server) handleTicks(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	count := 1
+	if c := q.Get("count"); c != "" {
+		n, err := strconv.Atoi(c)
+		if err != nil || n < 1 || n > 500 {
+			writeError(w, errorf(http.StatusBadRequest, "validation_error", "count must be between 1 and 500"))
+			return
+		}
+		count = n
+	}
+	daysAgo := 0
+	if d := q.Get("daysAgo"); d != "" {
+		n, err := strconv.Atoi(d)
+		if err != nil || n < 1 || n > 7 {
+			writeError(w, errorf(http.StatusBadRequest, "validation_error", "daysAgo must be between 1 and 7"))
+			return
+		}
+		daysAgo = n
+	}
+	var cursor int64
+	if c := q.Get("cursor"); c != "" {
+		var err error
+		if cursor, err = strconv.ParseInt(c, 10, 64); err != nil {
+			writeError(w, errorf(http.StatusBadRequest, "validation_error", "invalid cursor %q", c))
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, err := s.market(q.Get("market"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	day := s.Now().UTC().AddDate(0, 0, -daysAgo).Format("2006-01-02")
+	ticks := []*upbit.TradeTick{}
+	for i := len(m.trades) - 1; i >= 0 && len(ticks) < count; i-- {
+		tick := m.trades[i]
+		if tick.TradeDateUtc != day || cursor != 0 && tick.SequentialID >= cursor {
+			continue
+		}
+		ticks = append(ticks, tick)
+	}
+	writeJSON(w, ticks)
+}
+
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("upbittest: invalid time %q", s)
+}
+
+func (s *Server) handleTicker(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	markets, err := s.marketsParam(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	tickers := make([]upbit.Ticker, 0, len(markets))
+	for _, m := range markets {
+		tickers = append(tickers, m.ticker)
+	}
+	writeJSON(w, tickers)
+}
+
+func (s *Server) handleOrderbook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	markets, err := s.marketsParam(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	orderbooks := make([]*upbit.Orderbook, 0, len(markets))
+	for _, m := range markets {
+		orderbooks = append(orderbooks, m.orderbook())
+	}
+	writeJSON(w, orderbooks)
+}
+
+func (s *Server) marketsParam(r *http.Request) ([]*market, *apiError) {
+	codes := r.URL.Query().Get("markets")
+	if codes == "" {
+		return nil, errorf(http.StatusBadRequest, "validation_error", "markets is required")
+	}
+
+	var markets []*market
+	for _, code := range strings.Split(codes, ",") {
+		m, err := s.market(code)
+		if err != nil {
+			return nil, err
+		}
+		markets = append(markets, m)
+	}
+	return markets, nil
+}
+
+func (s *Server) handleAccounts(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.Accounts())
+}
+
+func (s *Server) handleChance(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, err := s.market(r.URL.Query().Get("market"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	chance := &upbit.Chance{
+		BidFee:      s.Fee,
+		AskFee:      s.Fee,
+		MakerBidFee: s.Fee,
+		MakerAskFee: s.Fee,
+		BidAccount:  *ledgerconv.Account(s.ledger.Account(m.quote)),
+		AskAccount:  *ledgerconv.Account(s.ledger.Account(m.base)),
+	}
+	chance.Market.ID = m.code.Market
+	chance.Market.Name = m.base + "/" + m.quote
+	chance.Market.OrderTypes = []string{upbit.OrdTypeLimit}
+	chance.Market.BidTypes = []string{upbit.OrdTypeLimit, upbit.OrdTypePrice}
+	chance.Market.AskTypes = []string{upbit.OrdTypeLimit, upbit.OrdTypeMarket}
+	chance.Market.OrderSides = []string{upbit.SideAsk, upbit.SideBid}
+	chance.Market.Bid.Currency = m.quote
+	chance.Market.Bid.MinTotal = m.minTotal
+	chance.Market.Ask.Currency = m.base
+	chance.Market.Ask.MinTotal = m.minTotal
+	chance.Market.MaxTotal = "1000000000"
+	chance.Market.State = upbit.MarketStateActive
+	writeJSON(w, chance)
+}
+
+func (s *Server) handleOrders(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		q, err := params(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		order, err := s.placeOrder(&upbit.OrderRequest{
+			Market:     q.Get("market"),
+			Side:       q.Get("side"),
+			Volume:     upbit.Decimal(q.Get("volume")),
+			Price:      upbit.Decimal(q.Get("price")),
+			OrdType:    q.Get("ord_type"),
+			Identifier: q.Get("identifier"),
+		})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, order)
+
+	case http.MethodGet:
+		writeJSON(w, s.listOrders(r.URL.Query()))
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleOrder(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var (
+		order *upbit.Order
+		err   *apiError
+	)
+	switch r.Method {
+	case http.MethodGet:
+		order, err = s.getOrder(q.Get("uuid"), q.Get("identifier"))
+	case http.MethodDelete:
+		order, err = s.cancelOrder(q.Get("uuid"), q.Get("identifier"))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, order)
+}
+
+func newUUID() string {
+	return uuid.New().String()
+}
@@ -0,0 +1,223 @@
+package upbittest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/investing-kr/go-upbit"
+	"github.com/investing-kr/go-upbit/upbittest"
+)
+
+func newServer(t *testing.T) (*upbittest.Server, *upbit.Client) {
+	srv := upbittest.NewServer()
+	if err := srv.AddMarket(upbit.MarketCode{Market: upbit.KRW_BTC, KoreanName: "비트코인", EnglishName: "Bitcoin"}); err != nil {
+		t.Fatal(err)
+	}
+	srv.SetBalance("KRW", "1000000")
+
+	client, err := srv.Client(&upbit.ClientOptions{RateLimitPolicy: upbit.RateLimitDisabled})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, client
+}
+
+func balances(t *testing.T, client *upbit.Client) map[string]*upbit.Account {
+	accounts, _, err := client.Accounts.Accounts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := map[string]*upbit.Account{}
+	for _, a := range accounts {
+		m[a.Currency] = a
+	}
+	return m
+}
+
+func TestAuthentication(t *testing.T) {
+	srv, _ := newServer(t)
+	defer srv.Close()
+
+	client, err := upbit.NewClient(nil, &upbit.ClientOptions{
+		AccessKey: srv.AccessKey,
+		SecretKey: "wrong",
+		ServerURL: srv.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = client.Orders.Chances(context.Background(), upbit.KRW_BTC)
+	if !errors.Is(err, upbit.ErrJWTVerification) {
+		t.Errorf("err = %v, want %v", err, upbit.ErrJWTVerification)
+	}
+}
+
+func TestLimitOrder(t *testing.T) {
+	srv, client := newServer(t)
+	defer srv.Close()
+	ctx := context.Background()
+
+	order, _, err := client.Orders.Order(ctx, &upbit.OrderRequest{
+		Market:     upbit.KRW_BTC,
+		Side:       upbit.SideBid,
+		OrdType:    upbit.OrdTypeLimit,
+		Price:      "50000000",
+		Volume:     "0.01",
+		Identifier: "bid-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.State != upbit.OrderStateWait {
+		t.Fatalf("state = %s, want wait", order.State)
+	}
+
+	krw := balances(t, client)["KRW"]
+	if !krw.Balance.Equal("499750") || !krw.Locked.Equal("500250") {
+		t.Errorf("KRW balance = %s, locked = %s", krw.Balance, krw.Locked)
+	}
+
+	// A trade above the bid leaves it waiting.
+	if err := srv.Trade(upbit.KRW_BTC, "51000000", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Trade(upbit.KRW_BTC, "50000000", "0.004"); err != nil {
+		t.Fatal(err)
+	}
+	order, _, err = client.Orders.GetOrderByIdentifier(ctx, "bid-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.State != upbit.OrderStateWait || !order.ExecutedVolume.Equal("0.004") || !order.RemainingVolume.Equal("0.006") {
+		t.Errorf("order = %+v", order)
+	}
+
+	order, _, err = client.Orders.CancelOrder(ctx, order.UUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.State != upbit.OrderStateCancel {
+		t.Errorf("state = %s, want cancel", order.State)
+	}
+
+	accounts := balances(t, client)
+	if krw := accounts["KRW"]; !krw.Balance.Equal("799900") || !krw.Locked.IsZero() {
+		t.Errorf("KRW balance = %s, locked = %s", krw.Balance, krw.Locked)
+	}
+	if btc := accounts["BTC"]; !btc.Balance.Equal("0.004") || !btc.AvgBuyPrice.Equal("50000000") {
+		t.Errorf("BTC balance = %s, avg = %s", btc.Balance, btc.AvgBuyPrice)
+	}
+
+	if _, _, err := client.Orders.CancelOrder(ctx, order.UUID); err == nil {
+		t.Error("cancelled order cancelled again")
+	}
+	if _, _, err := client.Orders.GetOrder(ctx, "unknown"); !errors.Is(err, upbit.ErrOrderNotFound) {
+		t.Errorf("err = %v, want %v", err, upbit.ErrOrderNotFound)
+	}
+
+	orders, _, err := client.Orders.ListOrders(ctx, &upbit.OrderListOptions{Market: upbit.KRW_BTC, States: []string{upbit.OrderStateCancel}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 1 {
+		t.Errorf("%d cancelled orders, want 1", len(orders))
+	}
+}
+
+func TestMarketOrders(t *testing.T) {
+	srv, client := newServer(t)
+	defer srv.Close()
+	ctx := context.Background()
+
+	srv.SetOrderbook(upbit.KRW_BTC,
+		[]upbit.BookLevel{{Price: "50000000", Size: "0.001"}, {Price: "51000000", Size: "1"}},
+		[]upbit.BookLevel{{Price: "49000000", Size: "1"}},
+	)
+
+	order, _, err := client.Orders.Order(ctx, &upbit.OrderRequest{
+		Market:  upbit.KRW_BTC,
+		Side:    upbit.SideBid,
+		OrdType: upbit.OrdTypePrice,
+		Price:   "101000",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.State != upbit.OrderStateDone || !order.ExecutedVolume.Equal("0.002") || order.TradesCount != 2 {
+		t.Errorf("bid = %+v", order)
+	}
+
+	order, _, err = client.Orders.Order(ctx, &upbit.OrderRequest{
+		Market:  upbit.KRW_BTC,
+		Side:    upbit.SideAsk,
+		OrdType: upbit.OrdTypeMarket,
+		Volume:  "0.002",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.State != upbit.OrderStateDone || !order.PaidFee.Equal("49") {
+		t.Errorf("ask = %+v", order)
+	}
+
+	ob, _, err := client.Quotations.OrderbookMarket(ctx, upbit.KRW_BTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ob.OrderbookUnits[0].AskPrice.Equal("51000000") || !ob.OrderbookUnits[0].BidSize.Equal("0.998") {
+		t.Errorf("orderbook = %+v", ob.OrderbookUnits)
+	}
+
+	_, _, err = client.Orders.Order(ctx, &upbit.OrderRequest{
+		Market:  upbit.KRW_BTC,
+		Side:    upbit.SideAsk,
+		OrdType: upbit.OrdTypeMarket,
+		Volume:  "1",
+	})
+	if !errors.Is(err, upbit.ErrInsufficientFundsAsk) {
+		t.Errorf("err = %v, want %v", err, upbit.ErrInsufficientFundsAsk)
+	}
+}
+
+func TestQuotations(t *testing.T) {
+	srv, client := newServer(t)
+	defer srv.Close()
+	ctx := context.Background()
+
+	srv.AddCandles(upbit.KRW_BTC, "minutes/1",
+		&upbit.Candle{Market: upbit.KRW_BTC, CandleDateTimeUtc: "2021-01-01T00:00:00", TradePrice: "1"},
+		&upbit.Candle{Market: upbit.KRW_BTC, CandleDateTimeUtc: "2021-01-01T00:01:00", TradePrice: "2"},
+		&upbit.Candle{Market: upbit.KRW_BTC, CandleDateTimeUtc: "2021-01-01T00:02:00", TradePrice: "3"},
+	)
+	candles, _, err := client.Candles.CandleMinutes(ctx, upbit.KRW_BTC, 1, &upbit.CandleListOptions{
+		To:    "2021-01-01T00:02:00Z",
+		Count: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 2 || candles[0].TradePrice != "2" {
+		t.Errorf("candles = %+v", candles)
+	}
+
+	srv.Trade(upbit.KRW_BTC, "50000000", "0.1")
+	ticker, _, err := client.Candles.TickerMarket(ctx, upbit.KRW_BTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticker.TradePrice != "50000000" {
+		t.Errorf("trade price = %s", ticker.TradePrice)
+	}
+
+	markets, _, err := client.Markets.All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(markets) != 1 || markets[0].KoreanName != "비트코인" {
+		t.Errorf("markets = %+v", markets)
+	}
+	if _, _, err := client.Candles.TickerMarket(ctx, "KRW-NONE"); !errors.Is(err, upbit.ErrMarketDoesNotExist) {
+		t.Errorf("err = %v, want %v", err, upbit.ErrMarketDoesNotExist)
+	}
+}
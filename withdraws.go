@@ -1,3 +1,182 @@
 package upbit
 
-// TODO
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/google/go-querystring/query"
+)
+
+func (s *WithdrawService) ListWithdraws(ctx context.Context, listOpt *WithdrawListOptions) ([]*Withdraw, *http.Response, error) {
+	qv, err := query.Values(listOpt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	queryString := qv.Encode()
+	u := fmt.Sprintf("v1/withdraws?%s", queryString)
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.client.generateToken(req, queryString)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	withdraws := []*Withdraw{}
+	resp, err := s.client.Do(ctx, req, &withdraws)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return withdraws, resp, nil
+}
+
+func (s *WithdrawService) getWithdraw(ctx context.Context, queryString string) (*Withdraw, *http.Response, error) {
+	u := fmt.Sprintf("v1/withdraw?%s", queryString)
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.client.generateToken(req, queryString)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	withdraw := &Withdraw{}
+	resp, err := s.client.Do(ctx, req, withdraw)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return withdraw, resp, nil
+}
+
+func (s *WithdrawService) GetWithdraw(ctx context.Context, uuid string) (*Withdraw, *http.Response, error) {
+	return s.GetWithdrawByUUID(ctx, uuid)
+}
+
+func (s *WithdrawService) GetWithdrawByUUID(ctx context.Context, uuid string) (*Withdraw, *http.Response, error) {
+	params := url.Values{}
+	params.Add("uuid", uuid)
+	qs := params.Encode()
+
+	return s.getWithdraw(ctx, qs)
+}
+
+func (s *WithdrawService) GetWithdrawByTxID(ctx context.Context, currency, txid string) (*Withdraw, *http.Response, error) {
+	params := url.Values{}
+	params.Add("currency", currency)
+	params.Add("txid", txid)
+	qs := params.Encode()
+
+	return s.getWithdraw(ctx, qs)
+}
+
+// Chance returns withdrawal constraints of the currency. netType may be empty
+// for currencies that have only one network.
+func (s *WithdrawService) Chance(ctx context.Context, currency, netType string) (*WithdrawChance, *http.Response, error) {
+	params := url.Values{}
+	params.Add("currency", currency)
+	if netType != "" {
+		params.Add("net_type", netType)
+	}
+	qs := params.Encode()
+
+	u := fmt.Sprintf("v1/withdraws/chance?%s", qs)
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.client.generateToken(req, qs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chance := new(WithdrawChance)
+	resp, err := s.client.Do(ctx, req, chance)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return chance, resp, nil
+}
+
+func (s *WithdrawService) post(ctx context.Context, path string, v interface{}) (*Withdraw, *http.Response, error) {
+	qv, err := query.Values(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	qs := qv.Encode()
+
+	u := fmt.Sprintf("%s?%s", path, qs)
+	req, err := s.client.NewRequest(http.MethodPost, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.client.generateToken(req, qs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	withdraw := &Withdraw{}
+	resp, err := s.client.Do(ctx, req, withdraw)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return withdraw, resp, nil
+}
+
+func (s *WithdrawService) WithdrawCoin(ctx context.Context, withdrawReq *WithdrawCoinRequest) (*Withdraw, *http.Response, error) {
+	if withdrawReq == nil || withdrawReq.Currency == "" || withdrawReq.Amount == "" || withdrawReq.Address == "" {
+		return nil, nil, ErrInvalidArguments
+	}
+
+	return s.post(ctx, "v1/withdraws/coin", withdrawReq)
+}
+
+func (s *WithdrawService) WithdrawKRW(ctx context.Context, withdrawReq *WithdrawKRWRequest) (*Withdraw, *http.Response, error) {
+	if err := s.client.supports(featureKRW); err != nil {
+		return nil, nil, err
+	}
+	if withdrawReq == nil || withdrawReq.Amount == "" {
+		return nil, nil, ErrInvalidArguments
+	}
+
+	return s.post(ctx, "v1/withdraws/krw", withdrawReq)
+}
+
+func (s *WithdrawService) CancelWithdraw(ctx context.Context, uuid string) (*Withdraw, *http.Response, error) {
+	params := url.Values{}
+	params.Add("uuid", uuid)
+	qs := params.Encode()
+
+	u := fmt.Sprintf("v1/withdraws/coin?%s", qs)
+	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.client.generateToken(req, qs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	withdraw := &Withdraw{}
+	resp, err := s.client.Do(ctx, req, withdraw)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return withdraw, resp, nil
+}
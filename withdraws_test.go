@@ -0,0 +1,70 @@
+package upbit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/investing-kr/go-upbit"
+)
+
+func TestWithdrawCoin(t *testing.T) {
+	client, closeServer := newPrivateClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/withdraws/coin":
+			if q.Get("currency") != "BTC" || q.Get("amount") != "0.01" || q.Get("address") != "addr" || q.Get("net_type") != "BTC" {
+				t.Errorf("withdraw query = %v", q)
+			}
+			w.Write([]byte(`{"type":"withdraw","uuid":"u","currency":"BTC","state":"submitting","amount":"0.01","fee":"0.0005"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/withdraws/coin" && q.Get("uuid") == "u":
+			w.Write([]byte(`{"type":"withdraw","uuid":"u","currency":"BTC","state":"canceled"}`))
+		case r.URL.Path == "/v1/withdraws/chance":
+			w.Write([]byte(`{"currency":{"code":"BTC"},"withdraw_limit":{"currency":"BTC","minimum":"0.001","can_withdraw":true}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer closeServer()
+	ctx := context.Background()
+
+	if _, _, err := client.Withdraws.WithdrawCoin(ctx, &upbit.WithdrawCoinRequest{Currency: "BTC", Amount: "0.01"}); !errors.Is(err, upbit.ErrInvalidArguments) {
+		t.Errorf("withdraw without address err = %v, want %v", err, upbit.ErrInvalidArguments)
+	}
+
+	withdraw, _, err := client.Withdraws.WithdrawCoin(ctx, &upbit.WithdrawCoinRequest{Currency: "BTC", NetType: "BTC", Amount: "0.01", Address: "addr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withdraw.UUID != "u" || !withdraw.Fee.Equal("0.0005") {
+		t.Errorf("withdraw = %+v", withdraw)
+	}
+
+	withdraw, _, err = client.Withdraws.CancelWithdraw(ctx, "u")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withdraw.State != "canceled" {
+		t.Errorf("canceled withdraw = %+v", withdraw)
+	}
+
+	chance, _, err := client.Withdraws.Chance(ctx, "BTC", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chance.Currency.Code != "BTC" {
+		t.Errorf("chance = %+v", chance)
+	}
+}
+
+func TestWithdrawKRWRegion(t *testing.T) {
+	client, err := upbit.NewClient(nil, &upbit.ClientOptions{AccessKey: "access", SecretKey: "secret", Region: upbit.RegionSG})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = client.Withdraws.WithdrawKRW(context.Background(), &upbit.WithdrawKRWRequest{Amount: "10000", TwoFactorType: "kakao_pay"})
+	if !errors.Is(err, upbit.ErrNotImplemented) {
+		t.Errorf("err = %v, want %v", err, upbit.ErrNotImplemented)
+	}
+}